@@ -0,0 +1,151 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// Operation selects a pixelwise arithmetic operation.
+type Operation int
+
+const (
+	// AddOperation adds the values.
+	AddOperation Operation = iota
+	// SubtractOperation subtracts the second value from the first.
+	SubtractOperation
+	// MultiplyOperation multiplies the values. Between two images the product is divided by max,
+	// so that max acts as 1; with a constant the value is multiplied by the constant as is.
+	MultiplyOperation
+	// DifferenceOperation takes the absolute difference of the values.
+	DifferenceOperation
+	// MinOperation keeps the smaller value.
+	MinOperation
+	// MaxOperation keeps the larger value.
+	MaxOperation
+)
+
+// Overflow selects how arithmetic results outside the [0, max] range are brought back into it.
+type Overflow int
+
+const (
+	// Saturate clips every result to the [0, max] range.
+	Saturate Overflow = iota
+	// Scale maps all results linearly from the range spanning them and [0, max] onto [0, max],
+	// so that no difference between results is lost. Results already in range are left unchanged.
+	Scale
+)
+
+// Arithmetic combines every channel with the operand channel of the same index: every value v
+// becomes v op w, where w is the operand value at the same place. Channels must have the same size.
+func Arithmetic(channels, operands [][][]uint8, operation Operation, max int, overflow Overflow) error {
+	if err := checkArithmetic(operation, overflow); err != nil {
+		return err
+	}
+	results := make([][][]float64, len(channels))
+	for c, channel := range channels {
+		operand := operands[c]
+		results[c] = arithmetic(channel, func(x, y int) float64 {
+			return float64(operand[y][x])
+		}, operation, float64(max))
+	}
+	fitResults(channels, results, max, overflow)
+	return nil
+}
+
+// ArithmeticConstant combines every value v of the channels with a constant: v becomes v op value.
+func ArithmeticConstant(channels [][][]uint8, operation Operation, value float64, max int, overflow Overflow) error {
+	if err := checkArithmetic(operation, overflow); err != nil {
+		return err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("invalid constant: %v", value)
+	}
+	results := make([][][]float64, len(channels))
+	for c, channel := range channels {
+		results[c] = arithmetic(channel, func(x, y int) float64 {
+			return value
+		}, operation, 1)
+	}
+	fitResults(channels, results, max, overflow)
+	return nil
+}
+
+// Mean returns the pixelwise average of a non-empty stack of channels of the same size.
+func Mean(stack [][][]uint8, max int) [][]uint8 {
+	width, height := ChannelSize(stack[0])
+	mean := NewChannel(width, height)
+	for y, row := range mean {
+		for x := range row {
+			sum := 0
+			for _, channel := range stack {
+				sum += int(channel[y][x])
+			}
+			row[x] = ClampValue(float64(sum)/float64(len(stack)), max)
+		}
+	}
+	return mean
+}
+
+// checkArithmetic validates an arithmetic operation and overflow mode.
+func checkArithmetic(operation Operation, overflow Overflow) error {
+	if operation < AddOperation || operation > MaxOperation {
+		return fmt.Errorf("invalid operation: %d", operation)
+	}
+	if overflow != Saturate && overflow != Scale {
+		return fmt.Errorf("invalid overflow mode: %d", overflow)
+	}
+	return nil
+}
+
+// arithmetic applies an operation between every value of a channel and the operand at the same place.
+// Products are divided by unit, the value standing for 1.
+func arithmetic(channel [][]uint8, operand func(x, y int) float64, operation Operation, unit float64) [][]float64 {
+	results := make([][]float64, len(channel))
+	for y, row := range channel {
+		results[y] = make([]float64, len(row))
+		for x, v := range row {
+			a, b := float64(v), operand(x, y)
+			switch operation {
+			case AddOperation:
+				results[y][x] = a + b
+			case SubtractOperation:
+				results[y][x] = a - b
+			case MultiplyOperation:
+				results[y][x] = a * b / unit
+			case DifferenceOperation:
+				results[y][x] = math.Abs(a - b)
+			case MinOperation:
+				results[y][x] = math.Min(a, b)
+			case MaxOperation:
+				results[y][x] = math.Max(a, b)
+			}
+		}
+	}
+	return results
+}
+
+// fitResults writes the arithmetic results back into the channels, brought into the [0, max] range
+// as selected by overflow. Scaling is the same for all channels so that colors keep their balance.
+func fitResults(channels [][][]uint8, results [][][]float64, max int, overflow Overflow) {
+	low, high := 0.0, float64(max)
+	if overflow == Scale {
+		for _, plane := range results {
+			for _, row := range plane {
+				for _, v := range row {
+					low, high = math.Min(low, v), math.Max(high, v)
+				}
+			}
+		}
+	}
+
+	for c, plane := range results {
+		for y, row := range plane {
+			for x, v := range row {
+				if high > low {
+					v = (v - low) * float64(max) / (high - low)
+				}
+				channels[c][y][x] = ClampValue(v, max)
+			}
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package raster
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Kernel is a convolution matrix applied around each pixel.
+// Data holds Height rows of Width weights; both sizes must be odd so the kernel has a center.
+type Kernel struct {
+	Width, Height int
+	Data          []float64
+	// Divisor divides the weighted sum. Zero means the sum of the weights,
+	// or 1 when the weights sum to zero.
+	Divisor float64
+	// Bias is added to the result after the division.
+	Bias float64
+}
+
+// EdgeMode selects how pixels past the border of the image are read.
+type EdgeMode int
+
+const (
+	// EdgeClamp repeats the border pixels.
+	EdgeClamp EdgeMode = iota
+	// EdgeMirror reflects the image across its border.
+	EdgeMirror
+	// EdgeWrap reads the opposite side of the image.
+	EdgeWrap
+	// EdgeZero treats pixels past the border as zero.
+	EdgeZero
+)
+
+// NewKernel creates a kernel from Height rows of Width weights.
+func NewKernel(width, height int, data []float64) (Kernel, error) {
+	kernel := Kernel{Width: width, Height: height, Data: data}
+	return kernel, kernel.validate()
+}
+
+// validate checks that the kernel sizes are odd and match its data.
+func (kernel Kernel) validate() error {
+	if kernel.Width <= 0 || kernel.Height <= 0 || kernel.Width%2 == 0 || kernel.Height%2 == 0 {
+		return fmt.Errorf("invalid kernel size %dx%d, sizes must be positive and odd", kernel.Width, kernel.Height)
+	}
+	if len(kernel.Data) != kernel.Width*kernel.Height {
+		return fmt.Errorf("kernel has %d weights, expected %d", len(kernel.Data), kernel.Width*kernel.Height)
+	}
+	return nil
+}
+
+// At returns the weight at column x and row y of the kernel.
+func (kernel Kernel) At(x, y int) float64 {
+	return kernel.Data[y*kernel.Width+x]
+}
+
+// divisor returns the effective divisor of the kernel.
+func (kernel Kernel) divisor() float64 {
+	if kernel.Divisor != 0 {
+		return kernel.Divisor
+	}
+	var sum float64
+	for _, w := range kernel.Data {
+		sum += w
+	}
+	if math.Abs(sum) < 1e-12 {
+		return 1
+	}
+	return sum
+}
+
+// separate splits the kernel into a column vector and a row vector whose outer product is the kernel.
+// It returns false when the kernel is not separable.
+func (kernel Kernel) separate() (column, row []float64, ok bool) {
+	pivotX, pivotY, largest := 0, 0, 0.0
+	for y := 0; y < kernel.Height; y++ {
+		for x := 0; x < kernel.Width; x++ {
+			if w := math.Abs(kernel.At(x, y)); w > largest {
+				pivotX, pivotY, largest = x, y, w
+			}
+		}
+	}
+	if largest == 0 {
+		return nil, nil, false
+	}
+
+	column = make([]float64, kernel.Height)
+	row = make([]float64, kernel.Width)
+	for y := range column {
+		column[y] = kernel.At(pivotX, y)
+	}
+	for x := range row {
+		row[x] = kernel.At(x, pivotY) / kernel.At(pivotX, pivotY)
+	}
+	for y := 0; y < kernel.Height; y++ {
+		for x := 0; x < kernel.Width; x++ {
+			if math.Abs(column[y]*row[x]-kernel.At(x, y)) > 1e-9*largest {
+				return nil, nil, false
+			}
+		}
+	}
+	return column, row, true
+}
+
+// BoxBlurKernel returns a (2*radius+1) square kernel averaging every pixel equally.
+func BoxBlurKernel(radius int) (Kernel, error) {
+	if radius < 0 {
+		return Kernel{}, fmt.Errorf("invalid radius: %d", radius)
+	}
+	size := 2*radius + 1
+	data := make([]float64, size*size)
+	for i := range data {
+		data[i] = 1
+	}
+	return Kernel{Width: size, Height: size, Data: data}, nil
+}
+
+// GaussianKernel returns a normalized Gaussian blur kernel reaching three standard deviations.
+func GaussianKernel(sigma float64) (Kernel, error) {
+	if !(sigma > 0) || math.IsInf(sigma, 1) {
+		return Kernel{}, fmt.Errorf("invalid standard deviation: %v", sigma)
+	}
+	return gaussianKernel(sigma), nil
+}
+
+// gaussianKernel returns the Gaussian kernel of a positive and finite standard deviation.
+func gaussianKernel(sigma float64) Kernel {
+	radius := int(math.Ceil(3 * sigma))
+	if radius < 1 {
+		radius = 1
+	}
+	size := 2*radius + 1
+	weights := make([]float64, size)
+	var sum float64
+	for i := range weights {
+		d := float64(i - radius)
+		weights[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += weights[i]
+	}
+	data := make([]float64, size*size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			data[y*size+x] = weights[y] * weights[x] / (sum * sum)
+		}
+	}
+	return Kernel{Width: size, Height: size, Data: data}
+}
+
+// SharpenKernel returns a 3x3 kernel enhancing details.
+func SharpenKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		0, -1, 0,
+		-1, 5, -1,
+		0, -1, 0,
+	}}
+}
+
+// EmbossKernel returns a 3x3 kernel giving a relief effect lit from the top left.
+func EmbossKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-2, -1, 0,
+		-1, 1, 1,
+		0, 1, 2,
+	}}
+}
+
+// LaplacianKernel returns a 3x3 Laplacian kernel. Its weights sum to zero,
+// so set Bias to half the max value to see negative responses.
+func LaplacianKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		0, 1, 0,
+		1, -4, 1,
+		0, 1, 0,
+	}}
+}
+
+// SobelXKernel returns the 3x3 Sobel kernel responding to horizontal changes.
+func SobelXKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-1, 0, 1,
+		-2, 0, 2,
+		-1, 0, 1,
+	}}
+}
+
+// SobelYKernel returns the 3x3 Sobel kernel responding to vertical changes.
+func SobelYKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-1, -2, -1,
+		0, 0, 0,
+		1, 2, 1,
+	}}
+}
+
+// LoadKernel reads a kernel from a text file.
+// Every line holds one row of weights separated by spaces, and every row must have the same length.
+// Lines "divisor <value>" and "bias <value>" set the divisor and the bias. Text after '#' is ignored.
+func LoadKernel(filename string) (Kernel, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return Kernel{}, err
+	}
+	defer file.Close()
+
+	kernel := Kernel{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToLower(fields[0]) {
+		case "divisor", "bias":
+			if len(fields) != 2 {
+				return Kernel{}, fmt.Errorf("invalid %s at line %d", fields[0], line)
+			}
+			value, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return Kernel{}, fmt.Errorf("invalid %s at line %d: %v", fields[0], line, err)
+			}
+			if strings.ToLower(fields[0]) == "divisor" {
+				kernel.Divisor = value
+			} else {
+				kernel.Bias = value
+			}
+			continue
+		}
+
+		if kernel.Width != 0 && len(fields) != kernel.Width {
+			return Kernel{}, fmt.Errorf("row at line %d has %d weights, expected %d", line, len(fields), kernel.Width)
+		}
+		kernel.Width = len(fields)
+		for _, field := range fields {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return Kernel{}, fmt.Errorf("invalid weight at line %d: %v", line, err)
+			}
+			kernel.Data = append(kernel.Data, value)
+		}
+		kernel.Height++
+	}
+	if err := scanner.Err(); err != nil {
+		return Kernel{}, err
+	}
+	if kernel.Height == 0 {
+		return Kernel{}, errors.New("empty kernel")
+	}
+	return kernel, kernel.validate()
+}
+
+// edgeIndex maps index i into [0, n) according to the edge mode.
+// It returns false when the pixel must be read as zero.
+func edgeIndex(i, n int, edge EdgeMode) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+	switch edge {
+	case EdgeMirror:
+		if n == 1 {
+			return 0, true
+		}
+		period := 2 * (n - 1)
+		i = ((i % period) + period) % period
+		if i >= n {
+			i = period - i
+		}
+		return i, true
+	case EdgeWrap:
+		return ((i % n) + n) % n, true
+	case EdgeZero:
+		return 0, false
+	}
+	return ClampInt(i, 0, n-1), true
+}
+
+// ConvolvePlane applies the kernel weights to a plane of values and returns the weighted sums.
+// Separable kernels are applied as a horizontal pass followed by a vertical pass.
+func ConvolvePlane(plane [][]float64, width, height int, kernel Kernel, edge EdgeMode) [][]float64 {
+	if column, row, ok := kernel.separate(); ok {
+		return convolve1D(convolve1D(plane, width, height, row, true, edge), width, height, column, false, edge)
+	}
+
+	rx, ry := kernel.Width/2, kernel.Height/2
+	result := NewPlane(width, height)
+	ParallelRows(height, func(start, end int) {
+		for y := start; y < end; y++ {
+			for x := 0; x < width; x++ {
+				var sum float64
+				for ky := 0; ky < kernel.Height; ky++ {
+					sy, ok := edgeIndex(y+ky-ry, height, edge)
+					if !ok {
+						continue
+					}
+					for kx := 0; kx < kernel.Width; kx++ {
+						sx, ok := edgeIndex(x+kx-rx, width, edge)
+						if !ok {
+							continue
+						}
+						sum += kernel.Data[ky*kernel.Width+kx] * plane[sy][sx]
+					}
+				}
+				result[y][x] = sum
+			}
+		}
+	})
+	return result
+}
+
+// convolve1D applies a one-dimensional kernel along rows (horizontal) or columns.
+func convolve1D(plane [][]float64, width, height int, weights []float64, horizontal bool, edge EdgeMode) [][]float64 {
+	radius := len(weights) / 2
+	result := NewPlane(width, height)
+	ParallelRows(height, func(start, end int) {
+		for y := start; y < end; y++ {
+			for x := 0; x < width; x++ {
+				var sum float64
+				for k, w := range weights {
+					if horizontal {
+						if sx, ok := edgeIndex(x+k-radius, width, edge); ok {
+							sum += w * plane[y][sx]
+						}
+					} else if sy, ok := edgeIndex(y+k-radius, height, edge); ok {
+						sum += w * plane[sy][x]
+					}
+				}
+				result[y][x] = sum
+			}
+		}
+	})
+	return result
+}
+
+// NewPlane allocates a plane of height rows of width values.
+func NewPlane(width, height int) [][]float64 {
+	plane := make([][]float64, height)
+	for y := range plane {
+		plane[y] = make([]float64, width)
+	}
+	return plane
+}
+
+// Plane returns the values of a channel as floating point numbers.
+func Plane(channel [][]uint8) [][]float64 {
+	width, height := ChannelSize(channel)
+	plane := NewPlane(width, height)
+	for y, row := range channel {
+		for x, v := range row {
+			plane[y][x] = float64(v)
+		}
+	}
+	return plane
+}
+
+// Convolve applies the kernel to each channel of an image with values up to max. The kernel is
+// laid over each pixel as written, without flipping, and results are clamped to [0, max].
+func Convolve(channels [][][]uint8, kernel Kernel, edge EdgeMode, max int) error {
+	if err := kernel.validate(); err != nil {
+		return err
+	}
+	divisor := kernel.divisor()
+	for _, channel := range channels {
+		width, height := ChannelSize(channel)
+		sums := ConvolvePlane(Plane(channel), width, height, kernel, edge)
+		for y, row := range channel {
+			for x := range row {
+				row[x] = ClampValue(sums[y][x]/divisor+kernel.Bias, max)
+			}
+		}
+	}
+	return nil
+}
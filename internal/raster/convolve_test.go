@@ -0,0 +1,59 @@
+package raster
+
+import "testing"
+
+func TestConvolveSeparable(t *testing.T) {
+	if _, _, ok := gaussianKernel(1).separate(); !ok {
+		t.Error("Gaussian kernel should be separable")
+	}
+	if _, _, ok := SobelXKernel().separate(); !ok {
+		t.Error("Sobel kernel should be separable")
+	}
+	if _, _, ok := LaplacianKernel().separate(); ok {
+		t.Error("Laplacian kernel should not be separable")
+	}
+
+	// The separable path gives the same result as the direct one.
+	const width, height = 15, 11
+	plane := NewPlane(width, height)
+	for y := range plane {
+		for x := range plane[y] {
+			plane[y][x] = float64((x*x + 3*y) % 17)
+		}
+	}
+	kernel := SobelXKernel()
+	separable := ConvolvePlane(plane, width, height, kernel, EdgeMirror)
+	kernel.Data[0] += 1e-3
+	direct := ConvolvePlane(plane, width, height, kernel, EdgeMirror)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			d := separable[y][x] - direct[y][x]
+			if d > 0.1 || d < -0.1 {
+				t.Errorf("Pixel at (%d, %d): separable %v, direct %v", x, y, separable[y][x], direct[y][x])
+			}
+		}
+	}
+}
+
+func TestEdgeIndex(t *testing.T) {
+	tests := []struct {
+		edge     EdgeMode
+		i        int
+		expected int
+		ok       bool
+	}{
+		{EdgeClamp, -2, 0, true},
+		{EdgeClamp, 7, 4, true},
+		{EdgeMirror, -1, 1, true},
+		{EdgeMirror, 5, 3, true},
+		{EdgeWrap, -1, 4, true},
+		{EdgeWrap, 6, 1, true},
+		{EdgeZero, -1, 0, false},
+	}
+	for _, test := range tests {
+		i, ok := edgeIndex(test.i, 5, test.edge)
+		if i != test.expected || ok != test.ok {
+			t.Errorf("Edge mode %d, index %d: expected %d %v, got %d %v", test.edge, test.i, test.expected, test.ok, i, ok)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package raster
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rect represents a rectangular area of an image.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Crop returns the rows of a width x height image inside rect.
+func Crop[T any](data [][]T, width, height int, rect Rect) ([][]T, error) {
+	if rect.Width <= 0 || rect.Height <= 0 || rect.X < 0 || rect.Y < 0 ||
+		rect.X+rect.Width > width || rect.Y+rect.Height > height {
+		return nil, fmt.Errorf("crop area %v out of the %dx%d image", rect, width, height)
+	}
+
+	newData := make([][]T, rect.Height)
+	for y := range newData {
+		newData[y] = make([]T, rect.Width)
+		copy(newData[y], data[rect.Y+y][rect.X:])
+	}
+	return newData, nil
+}
+
+// TrimBounds returns the smallest area holding every pixel that is not a border pixel,
+// or false if every pixel is a border pixel.
+func TrimBounds(width, height int, isBorder func(x, y int) bool) (Rect, bool) {
+	minX, minY, maxX, maxY := width, height, -1, -1
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if isBorder(x, y) {
+				continue
+			}
+			minX, maxX = min(minX, x), max(maxX, x)
+			minY, maxY = min(minY, y), max(maxY, y)
+		}
+	}
+	if maxX < 0 {
+		return Rect{}, false
+	}
+	return Rect{X: minX, Y: minY, Width: maxX - minX + 1, Height: maxY - minY + 1}, true
+}
+
+// Pad returns the rows of a width x height image grown by the given number of pixels on each side,
+// asking outside for the value of every new pixel in source coordinates.
+func Pad[T any](data [][]T, width, height, top, right, bottom, left int, outside func(x, y int) T) ([][]T, error) {
+	if top < 0 || right < 0 || bottom < 0 || left < 0 {
+		return nil, errors.New("padding must not be negative")
+	}
+	newData := make([][]T, height+top+bottom)
+	for y := range newData {
+		newData[y] = make([]T, width+left+right)
+		sy := y - top
+		for x := range newData[y] {
+			sx := x - left
+			if sx < 0 || sy < 0 || sx >= width || sy >= height {
+				newData[y][x] = outside(sx, sy)
+				continue
+			}
+			newData[y][x] = data[sy][sx]
+		}
+	}
+	return newData, nil
+}
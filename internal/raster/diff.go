@@ -0,0 +1,79 @@
+package raster
+
+import "fmt"
+
+// diffDimming is the brightness kept by unchanged pixels in a diff highlight image.
+const diffDimming = 1.0 / 3
+
+// DiffSummary describes the differences between two images.
+type DiffSummary struct {
+	// Changed is the number of pixels differing by more than the tolerance.
+	Changed int
+	// Bounds is the smallest rectangle holding every changed pixel, empty if none changed.
+	Bounds Rect
+	// MaxDelta is the largest difference between two values of a channel, over all pixels including
+	// tolerated ones.
+	MaxDelta int
+}
+
+// include counts the changed pixel at (x, y) and grows the bounds of the summary to hold it.
+func (summary *DiffSummary) include(x, y int) {
+	summary.Changed++
+	if summary.Changed == 1 {
+		summary.Bounds = Rect{X: x, Y: y, Width: 1, Height: 1}
+		return
+	}
+	bounds := &summary.Bounds
+	right, bottom := max(bounds.X+bounds.Width, x+1), max(bounds.Y+bounds.Height, y+1)
+	bounds.X, bounds.Y = min(bounds.X, x), min(bounds.Y, y)
+	bounds.Width, bounds.Height = right-bounds.X, bottom-bounds.Y
+}
+
+// Diff compares two images given as channels of the same size with values up to max. A pixel changed
+// if any of its values differ by more than tolerance. It returns the red, green and blue channels of an
+// image with a max value of 255 showing the changed pixels in red over a dimmed gray copy of a,
+// and a summary of the changes.
+func Diff(a, b [][][]uint8, max, tolerance int) ([3][][]uint8, DiffSummary, error) {
+	var highlight [3][][]uint8
+	var summary DiffSummary
+	if tolerance < 0 {
+		return highlight, summary, fmt.Errorf("invalid tolerance: %d", tolerance)
+	}
+
+	width, height := ChannelSize(a[0])
+	for c := range highlight {
+		highlight[c] = NewChannel(width, height)
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			delta, sum := 0, 0
+			for c := range a {
+				d := int(a[c][y][x]) - int(b[c][y][x])
+				if d < 0 {
+					d = -d
+				}
+				if d > delta {
+					delta = d
+				}
+				sum += int(a[c][y][x])
+			}
+			if delta > summary.MaxDelta {
+				summary.MaxDelta = delta
+			}
+			if delta > tolerance {
+				summary.include(x, y)
+				highlight[0][y][x] = 255
+				continue
+			}
+			var gray uint8
+			if max > 0 {
+				average := float64(sum) / float64(len(a))
+				gray = ClampValue(average*255/float64(max)*diffDimming, 255)
+			}
+			for c := range highlight {
+				highlight[c][y][x] = gray
+			}
+		}
+	}
+	return highlight, summary, nil
+}
@@ -0,0 +1,182 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// PrewittXKernel returns the 3x3 Prewitt kernel responding to horizontal changes.
+func PrewittXKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-1, 0, 1,
+		-1, 0, 1,
+		-1, 0, 1,
+	}}
+}
+
+// PrewittYKernel returns the 3x3 Prewitt kernel responding to vertical changes.
+func PrewittYKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-1, -1, -1,
+		0, 0, 0,
+		1, 1, 1,
+	}}
+}
+
+// ScharrXKernel returns the 3x3 Scharr kernel responding to horizontal changes.
+func ScharrXKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-3, 0, 3,
+		-10, 0, 10,
+		-3, 0, 3,
+	}}
+}
+
+// ScharrYKernel returns the 3x3 Scharr kernel responding to vertical changes.
+func ScharrYKernel() Kernel {
+	return Kernel{Width: 3, Height: 3, Data: []float64{
+		-3, -10, -3,
+		0, 0, 0,
+		3, 10, 3,
+	}}
+}
+
+// Operator is a pair of derivative kernels with the scale turning a step
+// from 0 to 1 into a derivative of 1.
+type Operator struct {
+	X, Y  Kernel
+	Scale float64
+}
+
+var (
+	// Sobel is the Sobel gradient operator.
+	Sobel = Operator{X: SobelXKernel(), Y: SobelYKernel(), Scale: 4}
+	// Prewitt is the Prewitt gradient operator.
+	Prewitt = Operator{X: PrewittXKernel(), Y: PrewittYKernel(), Scale: 3}
+	// Scharr is the Scharr gradient operator.
+	Scharr = Operator{X: ScharrXKernel(), Y: ScharrYKernel(), Scale: 16}
+)
+
+// gradient returns the horizontal and vertical derivatives of a plane.
+func (op Operator) gradient(plane [][]float64, width, height int) (gx, gy [][]float64) {
+	gx = ConvolvePlane(plane, width, height, op.X, EdgeClamp)
+	gy = ConvolvePlane(plane, width, height, op.Y, EdgeClamp)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			gx[y][x] /= op.Scale
+			gy[y][x] /= op.Scale
+		}
+	}
+	return gx, gy
+}
+
+// Gradient applies the operator to a channel with values up to max and returns two channels.
+// In the magnitude channel a sharp step from black to white reaches max. In the direction
+// channel the angle of the gradient, from -180 to 180 degrees, is spread over [0, max].
+func Gradient(channel [][]uint8, op Operator, max int) (magnitude, direction [][]uint8) {
+	width, height := ChannelSize(channel)
+	gx, gy := op.gradient(Plane(channel), width, height)
+	magnitude, direction = NewChannel(width, height), NewChannel(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			magnitude[y][x] = ClampValue(math.Hypot(gx[y][x], gy[y][x]), max)
+			angle := math.Atan2(gy[y][x], gx[y][x])
+			direction[y][x] = ClampValue((angle+math.Pi)/(2*math.Pi)*float64(max), max)
+		}
+	}
+	return magnitude, direction
+}
+
+// Canny runs the Canny edge detector on a channel with values up to max and reports the edge pixels.
+// The sigma must be zero (no smoothing) or positive and finite, and the thresholds must satisfy
+// 0 <= low <= high; both are fractions of max.
+func Canny(channel [][]uint8, max int, sigma, low, high float64) ([][]bool, error) {
+	if !(sigma >= 0) || math.IsInf(sigma, 1) {
+		return nil, fmt.Errorf("invalid standard deviation: %v", sigma)
+	}
+	if !(low >= 0 && low <= high) {
+		return nil, fmt.Errorf("invalid thresholds: %v and %v", low, high)
+	}
+	width, height := ChannelSize(channel)
+	plane := Plane(channel)
+	if sigma > 0 {
+		plane = ConvolvePlane(plane, width, height, gaussianKernel(sigma), EdgeClamp)
+	}
+	gx, gy := Sobel.gradient(plane, width, height)
+	magnitude := NewPlane(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			magnitude[y][x] = math.Hypot(gx[y][x], gy[y][x]) / float64(max)
+		}
+	}
+
+	// Non-maximum suppression: keep pixels that are the largest across the edge.
+	thin := NewPlane(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			m := magnitude[y][x]
+			if m == 0 {
+				continue
+			}
+			dx, dy := gradientStep(gx[y][x], gy[y][x])
+			before, after := 0.0, 0.0
+			if bx, by := x-dx, y-dy; bx >= 0 && by >= 0 && bx < width && by < height {
+				before = magnitude[by][bx]
+			}
+			if ax, ay := x+dx, y+dy; ax >= 0 && ay >= 0 && ax < width && ay < height {
+				after = magnitude[ay][ax]
+			}
+			if m >= before && m > after {
+				thin[y][x] = m
+			}
+		}
+	}
+
+	// Hysteresis: grow edges from strong pixels through weak ones.
+	edges := make([][]bool, height)
+	for y := range edges {
+		edges[y] = make([]bool, width)
+	}
+	var stack [][2]int
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if thin[y][x] >= high && thin[y][x] > 0 && !edges[y][x] {
+				edges[y][x] = true
+				stack = append(stack, [2]int{x, y})
+			}
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for ny := p[1] - 1; ny <= p[1]+1; ny++ {
+					for nx := p[0] - 1; nx <= p[0]+1; nx++ {
+						if nx < 0 || ny < 0 || nx >= width || ny >= height || edges[ny][nx] {
+							continue
+						}
+						if thin[ny][nx] >= low && thin[ny][nx] > 0 {
+							edges[ny][nx] = true
+							stack = append(stack, [2]int{nx, ny})
+						}
+					}
+				}
+			}
+		}
+	}
+	return edges, nil
+}
+
+// gradientStep returns the neighbor offset closest to the gradient direction.
+func gradientStep(gx, gy float64) (int, int) {
+	angle := math.Atan2(gy, gx) * 180 / math.Pi
+	if angle < 0 {
+		angle += 180
+	}
+	switch {
+	case angle < 22.5 || angle >= 157.5:
+		return 1, 0
+	case angle < 67.5:
+		return 1, 1
+	case angle < 112.5:
+		return 0, 1
+	}
+	return -1, 1
+}
@@ -0,0 +1,255 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// Equalize spreads the values of each channel so that its histogram becomes as flat as possible.
+func Equalize(channels [][][]uint8, max int) {
+	for _, channel := range channels {
+		ApplyLUT(channel, equalizeLUT(Histogram(channel, max), max))
+	}
+}
+
+// CLAHE applies contrast-limited adaptive histogram equalization to each channel.
+// See claheChannel for the meaning of the parameters.
+func CLAHE(channels [][][]uint8, max, tilesX, tilesY int, clipLimit float64) error {
+	for _, channel := range channels {
+		width, height := ChannelSize(channel)
+		if err := checkCLAHEParameters(width, height, tilesX, tilesY, clipLimit); err != nil {
+			return err
+		}
+	}
+	for _, channel := range channels {
+		width, height := ChannelSize(channel)
+		copy(channel, claheChannel(channel, width, height, max, tilesX, tilesY, clipLimit))
+	}
+	return nil
+}
+
+// MatchHistogram remaps a channel so that its histogram resembles the reference histogram,
+// which counts values up to referenceMax.
+func MatchHistogram(channel [][]uint8, max int, reference []int, referenceMax int) {
+	ApplyLUT(channel, matchLUT(Histogram(channel, max), reference, max, referenceMax))
+}
+
+// AutoContrast stretches the values of the channels to the full range, in the manner of pnmnorm:
+// the darkest lowPercent of the values become 0, the brightest highPercent become max, and values
+// in between are spread linearly. The same stretch applies to every channel.
+func AutoContrast(channels [][][]uint8, max int, lowPercent, highPercent float64) error {
+	if err := checkStretchParameters(lowPercent, highPercent); err != nil {
+		return err
+	}
+	counts := make([]int, max+1)
+	for _, channel := range channels {
+		for v, n := range Histogram(channel, max) {
+			counts[v] += n
+		}
+	}
+	lut := stretchLUT(counts, max, lowPercent, highPercent)
+	for _, channel := range channels {
+		ApplyLUT(channel, lut)
+	}
+	return nil
+}
+
+// Histogram counts the values of a channel from 0 to max; values above max are counted as max.
+func Histogram(channel [][]uint8, max int) []int {
+	counts := make([]int, max+1)
+	for _, row := range channel {
+		for _, v := range row {
+			counts[min(int(v), max)]++
+		}
+	}
+	return counts
+}
+
+// ApplyLUT replaces every value of a channel by its entry in the lookup table.
+func ApplyLUT(channel [][]uint8, lut []uint8) {
+	for _, row := range channel {
+		for x, v := range row {
+			row[x] = lut[min(int(v), len(lut)-1)]
+		}
+	}
+}
+
+// equalizeLUT returns the lookup table mapping every value to its rank in the cumulative histogram,
+// the darkest value present becoming 0 and the brightest max.
+func equalizeLUT(counts []int, max int) []uint8 {
+	lut := make([]uint8, len(counts))
+	total, first := 0, -1
+	for _, n := range counts {
+		total += n
+		if first < 0 && n > 0 {
+			first = n
+		}
+	}
+	if first < 0 || total == first {
+		// No pixels or a single value, nothing to spread.
+		for v := range lut {
+			lut[v] = uint8(v)
+		}
+		return lut
+	}
+	cumulative := 0
+	for v, n := range counts {
+		cumulative += n
+		if cumulative > first {
+			lut[v] = uint8(math.Round(float64(cumulative-first) * float64(max) / float64(total-first)))
+		}
+	}
+	return lut
+}
+
+// checkCLAHEParameters validates the tile grid and clip limit of CLAHE.
+func checkCLAHEParameters(width, height, tilesX, tilesY int, clipLimit float64) error {
+	if tilesX <= 0 || tilesY <= 0 || tilesX > width || tilesY > height {
+		return fmt.Errorf("invalid tile grid %dx%d for a %dx%d image", tilesX, tilesY, width, height)
+	}
+	if clipLimit < 1 || math.IsNaN(clipLimit) {
+		return fmt.Errorf("invalid clip limit: %v", clipLimit)
+	}
+	return nil
+}
+
+// claheChannel equalizes a channel separately in each tile of a tilesX x tilesY grid.
+// No value may be counted more than clipLimit times the average count per value in a tile;
+// the excess is spread over all values, which limits how much noise the equalization amplifies.
+// Low clip limits keep the result close to the original, usual values are between 2 and 4.
+// The lookup tables of the four nearest tiles are blended bilinearly to avoid seams.
+func claheChannel(channel [][]uint8, width, height, max, tilesX, tilesY int, clipLimit float64) [][]uint8 {
+	bins := max + 1
+	luts := make([][][]uint8, tilesY)
+	for ty := range luts {
+		luts[ty] = make([][]uint8, tilesX)
+		y0, y1 := ty*height/tilesY, (ty+1)*height/tilesY
+		for tx := range luts[ty] {
+			x0, x1 := tx*width/tilesX, (tx+1)*width/tilesX
+			counts := make([]int, bins)
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					counts[min(int(channel[y][x]), max)]++
+				}
+			}
+			area := (x1 - x0) * (y1 - y0)
+			clipHistogram(counts, int(math.Max(1, clipLimit*float64(area)/float64(bins))))
+
+			lut := make([]uint8, bins)
+			cumulative := 0
+			for v, n := range counts {
+				cumulative += n
+				lut[v] = uint8(math.Round(float64(cumulative) * float64(max) / float64(area)))
+			}
+			luts[ty][tx] = lut
+		}
+	}
+
+	tileWidth, tileHeight := float64(width)/float64(tilesX), float64(height)/float64(tilesY)
+	result := make([][]uint8, height)
+	ParallelRows(height, func(start, end int) {
+		for y := start; y < end; y++ {
+			result[y] = make([]uint8, width)
+			ty0, ty1, wy := tileBlend(y, tileHeight, tilesY)
+			for x := 0; x < width; x++ {
+				tx0, tx1, wx := tileBlend(x, tileWidth, tilesX)
+				v := min(int(channel[y][x]), max)
+				top := (1-wx)*float64(luts[ty0][tx0][v]) + wx*float64(luts[ty0][tx1][v])
+				bottom := (1-wx)*float64(luts[ty1][tx0][v]) + wx*float64(luts[ty1][tx1][v])
+				result[y][x] = uint8(math.Round((1-wy)*top + wy*bottom))
+			}
+		}
+	})
+	return result
+}
+
+// clipHistogram lowers the counts above limit and spreads the excess evenly over all values.
+func clipHistogram(counts []int, limit int) {
+	excess := 0
+	for v, n := range counts {
+		if n > limit {
+			excess += n - limit
+			counts[v] = limit
+		}
+	}
+	share, rest := excess/len(counts), excess%len(counts)
+	for v := range counts {
+		counts[v] += share
+	}
+	// The remainder goes to values spread over the whole range.
+	for i := 0; i < rest; i++ {
+		counts[i*len(counts)/rest]++
+	}
+}
+
+// tileBlend returns the two tiles whose centers surround coordinate i and the weight of the second one.
+func tileBlend(i int, tileSize float64, tiles int) (int, int, float64) {
+	f := (float64(i)+0.5)/tileSize - 0.5
+	first := ClampInt(int(math.Floor(f)), 0, tiles-1)
+	second := min(first+1, tiles-1)
+	return first, second, math.Max(0, math.Min(1, f-float64(first)))
+}
+
+// matchLUT returns the lookup table giving every value of the source histogram the value of the reference
+// histogram with the same cumulative proportion of pixels, scaled from the reference max to max.
+func matchLUT(source, reference []int, max, referenceMax int) []uint8 {
+	sourceTotal, referenceTotal := 0, 0
+	for _, n := range source {
+		sourceTotal += n
+	}
+	for _, n := range reference {
+		referenceTotal += n
+	}
+
+	lut := make([]uint8, len(source))
+	sourceCumulative, referenceCumulative, r := 0, reference[0], 0
+	for v, n := range source {
+		sourceCumulative += n
+		// Advance to the first reference value covering the same proportion of pixels.
+		for r < referenceMax && referenceCumulative*sourceTotal < sourceCumulative*referenceTotal {
+			r++
+			referenceCumulative += reference[r]
+		}
+		if referenceMax > 0 {
+			lut[v] = uint8(ClampInt((r*max+referenceMax/2)/referenceMax, 0, max))
+		}
+	}
+	return lut
+}
+
+// checkStretchParameters validates the clipped percentages of an auto-contrast stretch.
+func checkStretchParameters(lowPercent, highPercent float64) error {
+	if lowPercent < 0 || highPercent < 0 || lowPercent+highPercent >= 100 || math.IsNaN(lowPercent+highPercent) {
+		return fmt.Errorf("invalid percentages: %v and %v", lowPercent, highPercent)
+	}
+	return nil
+}
+
+// stretchLUT returns the lookup table mapping the value below which lie lowPercent of the pixels to 0
+// and the value above which lie highPercent of them to max.
+func stretchLUT(counts []int, max int, lowPercent, highPercent float64) []uint8 {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	low, seen := 0, 0
+	for low < max && float64(seen+counts[low]) <= lowPercent/100*float64(total) {
+		seen += counts[low]
+		low++
+	}
+	high, seen := max, 0
+	for high > 0 && float64(seen+counts[high]) <= highPercent/100*float64(total) {
+		seen += counts[high]
+		high--
+	}
+
+	lut := make([]uint8, len(counts))
+	for v := range lut {
+		if high <= low {
+			lut[v] = uint8(v)
+			continue
+		}
+		lut[v] = uint8(ClampInt(int(math.Round(float64(v-low)*float64(max)/float64(high-low))), 0, max))
+	}
+	return lut
+}
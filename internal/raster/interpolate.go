@@ -0,0 +1,117 @@
+package raster
+
+import "math"
+
+// Interpolation selects how pixel values are computed between pixel centers.
+type Interpolation int
+
+const (
+	// NearestNeighbor uses the value of the closest pixel.
+	NearestNeighbor Interpolation = iota
+	// Bilinear blends the 2x2 closest pixels.
+	Bilinear
+	// Bicubic blends the 4x4 closest pixels with a cubic convolution kernel.
+	Bicubic
+)
+
+// linearWeight is the triangle kernel used by bilinear interpolation.
+func linearWeight(t float64) float64 {
+	t = math.Abs(t)
+	if t < 1 {
+		return 1 - t
+	}
+	return 0
+}
+
+// cubicWeight is the Keys cubic convolution kernel with a = -0.5 (Catmull-Rom).
+func cubicWeight(t float64) float64 {
+	const a = -0.5
+	t = math.Abs(t)
+	switch {
+	case t < 1:
+		return (a+2)*t*t*t - (a+3)*t*t + 1
+	case t < 2:
+		return a*t*t*t - 5*a*t*t + 8*a*t - 4*a
+	}
+	return 0
+}
+
+// tap is a source pixel and its share of an interpolated value.
+type tap struct {
+	x, y   int
+	weight float64
+}
+
+// sampleTaps appends to taps the source pixels blended to interpolate a width x height image
+// at (x, y), pixel centers being at integer coordinates. It returns false for points outside
+// the image; kernels reaching past the border replicate the edge pixels.
+func sampleTaps(taps []tap, x, y float64, width, height int, interp Interpolation) ([]tap, bool) {
+	if x < -0.5 || y < -0.5 || x > float64(width)-0.5 || y > float64(height)-0.5 {
+		return taps, false
+	}
+	radius, weight := 0, linearWeight
+	switch interp {
+	case Bilinear:
+		radius = 1
+	case Bicubic:
+		radius, weight = 2, cubicWeight
+	default:
+		ix := ClampInt(int(math.Floor(x+0.5)), 0, width-1)
+		iy := ClampInt(int(math.Floor(y+0.5)), 0, height-1)
+		return append(taps, tap{ix, iy, 1}), true
+	}
+
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	first := len(taps)
+	var total float64
+	for j := y0 - radius + 1; j <= y0+radius; j++ {
+		wy := weight(y - float64(j))
+		if wy == 0 {
+			continue
+		}
+		for i := x0 - radius + 1; i <= x0+radius; i++ {
+			w := wy * weight(x-float64(i))
+			taps = append(taps, tap{ClampInt(i, 0, width-1), ClampInt(j, 0, height-1), w})
+			total += w
+		}
+	}
+	if total == 0 {
+		return taps[:first], true
+	}
+	for i := first; i < len(taps); i++ {
+		taps[i].weight /= total
+	}
+	return taps, true
+}
+
+// Warp resamples the channels of an image onto a new width x height grid.
+// For every destination pixel, inverse returns the matching source coordinates,
+// or false when the pixel has no source and every channel must take its fill value.
+func Warp(channels [][][]uint8, width, height int, inverse func(x, y float64) (float64, float64, bool), interp Interpolation, max int, fill []uint8) [][][]uint8 {
+	sourceWidth, sourceHeight := ChannelSize(channels[0])
+	results := make([][][]uint8, len(channels))
+	for c := range results {
+		results[c] = NewChannel(width, height)
+	}
+	var taps []tap
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			sx, sy, ok := inverse(float64(x), float64(y))
+			if ok {
+				taps, ok = sampleTaps(taps[:0], sx, sy, sourceWidth, sourceHeight, interp)
+			}
+			for c, channel := range channels {
+				if !ok {
+					results[c][y][x] = fill[c]
+					continue
+				}
+				var sum float64
+				for _, t := range taps {
+					sum += t.weight * float64(channel[t.y][t.x])
+				}
+				results[c][y][x] = ClampValue(sum, max)
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,161 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// msssimWeights are the weights of the five scales of MS-SSIM, from the finest to the coarsest,
+// as measured by Wang, Simoncelli and Bovik.
+var msssimWeights = []float64{0.0448, 0.2856, 0.3001, 0.2363, 0.1333}
+
+// MSE returns the mean squared error between two non-empty channels of the same size.
+func MSE(a, b [][]uint8) float64 {
+	width, height := ChannelSize(a)
+	return squaredError(a, b) / float64(width*height)
+}
+
+// PSNR converts a mean squared error into a peak signal-to-noise ratio in decibels for values
+// up to max, as pnmpsnr does: 10 log10(max² / MSE). A zero error gives +Inf.
+func PSNR(mse float64, max int) float64 {
+	return psnr(mse, max)
+}
+
+// SSIM returns the structural similarity between two images given as non-empty channels of the same
+// size with values up to max, averaged over the channels, and the map of the similarity of every pixel
+// as a channel with values up to 255 where negative similarities are 0.
+// Statistics are gathered in a Gaussian window with a standard deviation of 1.5 pixels.
+func SSIM(a, b [][][]uint8, max int) (float64, [][]uint8) {
+	width, height := ChannelSize(a[0])
+	average := NewPlane(width, height)
+	for c := range a {
+		similarity, _ := ssimMaps(Plane(a[c]), Plane(b[c]), width, height, float64(max))
+		for y, row := range similarity {
+			for x, v := range row {
+				average[y][x] += v / float64(len(a))
+			}
+		}
+	}
+	similarityMap := NewChannel(width, height)
+	for y, row := range average {
+		for x, v := range row {
+			similarityMap[y][x] = ClampValue(v*255, 255)
+		}
+	}
+	return mean(average), similarityMap
+}
+
+// MSSSIM returns the multi-scale structural similarity between two images given as channels of the
+// same size with values up to max, averaged over the channels. The images are compared at five scales,
+// halving their size each time, so they must be at least 16 pixels wide and high.
+func MSSSIM(a, b [][][]uint8, max int) (float64, error) {
+	width, height := ChannelSize(a[0])
+	var sum float64
+	for c := range a {
+		similarity, err := msssim(Plane(a[c]), Plane(b[c]), width, height, float64(max))
+		if err != nil {
+			return 0, err
+		}
+		sum += similarity
+	}
+	return sum / float64(len(a)), nil
+}
+
+// squaredError returns the sum of the squared differences between two channels.
+func squaredError(a, b [][]uint8) float64 {
+	var sum float64
+	for y, row := range a {
+		for x, v := range row {
+			d := float64(v) - float64(b[y][x])
+			sum += d * d
+		}
+	}
+	return sum
+}
+
+// psnr converts a mean squared error into a peak signal-to-noise ratio for values up to max.
+func psnr(mse float64, max int) float64 {
+	if mse == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(float64(max)*float64(max)/mse)
+}
+
+// mean returns the average of a plane.
+func mean(plane [][]float64) float64 {
+	var sum float64
+	n := 0
+	for _, row := range plane {
+		for _, v := range row {
+			sum += v
+		}
+		n += len(row)
+	}
+	return sum / float64(n)
+}
+
+// ssimMaps returns the structural similarity of every pixel of two planes with values up to max,
+// and its contrast-structure part, which leaves out the comparison of the mean luminances.
+func ssimMaps(a, b [][]float64, width, height int, max float64) (similarity, contrastStructure [][]float64) {
+	c1, c2 := (0.01*max)*(0.01*max), (0.03*max)*(0.03*max)
+	window := gaussianKernel(1.5)
+	blur := func(plane [][]float64) [][]float64 {
+		return ConvolvePlane(plane, width, height, window, EdgeClamp)
+	}
+	product := func(p, q [][]float64) [][]float64 {
+		result := NewPlane(width, height)
+		for y := range result {
+			for x := range result[y] {
+				result[y][x] = p[y][x] * q[y][x]
+			}
+		}
+		return result
+	}
+
+	meanA, meanB := blur(a), blur(b)
+	squaresA, squaresB, products := blur(product(a, a)), blur(product(b, b)), blur(product(a, b))
+	similarity, contrastStructure = NewPlane(width, height), NewPlane(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			ma, mb := meanA[y][x], meanB[y][x]
+			varianceA, varianceB := squaresA[y][x]-ma*ma, squaresB[y][x]-mb*mb
+			covariance := products[y][x] - ma*mb
+			cs := (2*covariance + c2) / (varianceA + varianceB + c2)
+			contrastStructure[y][x] = cs
+			similarity[y][x] = (2*ma*mb + c1) / (ma*ma + mb*mb + c1) * cs
+		}
+	}
+	return similarity, contrastStructure
+}
+
+// msssim combines the contrast-structure similarity of two planes at the finer scales with their
+// full similarity at the coarsest one.
+func msssim(a, b [][]float64, width, height int, max float64) (float64, error) {
+	if smallest := 1 << (len(msssimWeights) - 1); width < smallest || height < smallest {
+		return 0, fmt.Errorf("images of %dx%d are too small for MS-SSIM, at least %dx%d is needed",
+			width, height, smallest, smallest)
+	}
+	result := 1.0
+	for scale, weight := range msssimWeights {
+		similarity, contrastStructure := ssimMaps(a, b, width, height, max)
+		if scale == len(msssimWeights)-1 {
+			result *= math.Pow(math.Max(0, mean(similarity)), weight)
+			break
+		}
+		result *= math.Pow(math.Max(0, mean(contrastStructure)), weight)
+		a, b = halve(a, width, height), halve(b, width, height)
+		width, height = width/2, height/2
+	}
+	return result, nil
+}
+
+// halve downsamples a plane by averaging blocks of 2x2 values; an odd last row or column is dropped.
+func halve(plane [][]float64, width, height int) [][]float64 {
+	result := NewPlane(width/2, height/2)
+	for y := range result {
+		for x := range result[y] {
+			result[y][x] = (plane[2*y][2*x] + plane[2*y][2*x+1] + plane[2*y+1][2*x] + plane[2*y+1][2*x+1]) / 4
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// WindowShape selects the neighborhood used by rank filters.
+type WindowShape int
+
+const (
+	// SquareWindow covers a (2*radius+1) square.
+	SquareWindow WindowShape = iota
+	// CircularWindow covers the pixels within radius of the center.
+	CircularWindow
+)
+
+// checkRankParameters validates the radius and percentile of a rank filter.
+func checkRankParameters(radius int, percentile float64) error {
+	if radius < 0 {
+		return fmt.Errorf("invalid radius: %d", radius)
+	}
+	if percentile < 0 || percentile > 100 || math.IsNaN(percentile) {
+		return fmt.Errorf("invalid percentile: %v", percentile)
+	}
+	return nil
+}
+
+// windowSpans returns, for every row offset from -radius to radius, the horizontal half-width of the window.
+func windowSpans(radius int, shape WindowShape) []int {
+	spans := make([]int, 2*radius+1)
+	for dy := -radius; dy <= radius; dy++ {
+		spans[dy+radius] = radius
+		if shape == CircularWindow {
+			spans[dy+radius] = int(math.Sqrt(float64(radius*radius - dy*dy)))
+		}
+	}
+	return spans
+}
+
+// RankFilter replaces every value of each channel by the given percentile (0 to 100) of its
+// neighborhood, each channel being filtered on its own. Neighborhoods are clipped at the border.
+func RankFilter(channels [][][]uint8, radius int, percentile float64, shape WindowShape) error {
+	if err := checkRankParameters(radius, percentile); err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		width, height := ChannelSize(channel)
+		copy(channel, rankFilter(channel, width, height, radius, percentile, shape))
+	}
+	return nil
+}
+
+// rankFilter applies a rank filter to a channel with a histogram sliding along each row:
+// moving one pixel right only removes the left column of the window and adds the right one,
+// so the cost per pixel grows with the radius instead of its square.
+func rankFilter(channel [][]uint8, width, height, radius int, percentile float64, shape WindowShape) [][]uint8 {
+	spans := windowSpans(radius, shape)
+	result := make([][]uint8, height)
+
+	ParallelRows(height, func(start, end int) {
+		var histogram [256]int
+		for y := start; y < end; y++ {
+			result[y] = make([]uint8, width)
+			histogram = [256]int{}
+			count := 0
+			update := func(x, dy, delta int) {
+				sy := y + dy
+				if x < 0 || x >= width || sy < 0 || sy >= height {
+					return
+				}
+				histogram[channel[sy][x]] += delta
+				count += delta
+			}
+
+			for dy := -radius; dy <= radius; dy++ {
+				for x := -spans[dy+radius]; x <= spans[dy+radius]; x++ {
+					update(x, dy, 1)
+				}
+			}
+			for x := 0; x < width; x++ {
+				if x > 0 {
+					for dy := -radius; dy <= radius; dy++ {
+						span := spans[dy+radius]
+						update(x-1-span, dy, -1)
+						update(x+span, dy, 1)
+					}
+				}
+				result[y][x] = histogramRank(&histogram, count, percentile)
+			}
+		}
+	})
+	return result
+}
+
+// histogramRank returns the value at the given percentile of a histogram holding count values.
+func histogramRank(histogram *[256]int, count int, percentile float64) uint8 {
+	target := int(math.Round(percentile / 100 * float64(count-1)))
+	seen := 0
+	for value, n := range histogram {
+		seen += n
+		if seen > target {
+			return uint8(value)
+		}
+	}
+	return 255
+}
@@ -0,0 +1,76 @@
+// Package raster holds the image processing algorithms shared by the pbm, pgm and ppm packages.
+// They work on channels, rows of 8-bit values with a max value, so that a PGM image is one channel
+// and a PPM image is three.
+package raster
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// NewChannel allocates a channel of width x height values.
+func NewChannel(width, height int) [][]uint8 {
+	channel := make([][]uint8, height)
+	for y := range channel {
+		channel[y] = make([]uint8, width)
+	}
+	return channel
+}
+
+// ChannelSize returns the width and height of a channel.
+func ChannelSize(channel [][]uint8) (int, int) {
+	if len(channel) == 0 {
+		return 0, 0
+	}
+	return len(channel[0]), len(channel)
+}
+
+// ClampInt limits v to the [low, high] range.
+func ClampInt(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
+
+// ClampValue rounds v and limits it to the [0, max] range.
+func ClampValue(v float64, max int) uint8 {
+	v = math.Round(v)
+	if v < 0 {
+		return 0
+	}
+	if v > float64(max) {
+		return uint8(max)
+	}
+	return uint8(v)
+}
+
+// ParallelRows calls fn on consecutive row ranges covering [0, rows), spread over the available CPUs.
+func ParallelRows(rows int, fn func(start, end int)) {
+	workers := runtime.NumCPU()
+	if workers > rows {
+		workers = rows
+	}
+	if workers <= 1 {
+		fn(0, rows)
+		return
+	}
+	chunk := (rows + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < rows; start += chunk {
+		end := start + chunk
+		if end > rows {
+			end = rows
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,24 @@
+package raster
+
+// Reduce shrinks a width x height bitmap by a factor n into a channel with a max value of 255,
+// in the manner of pbmreduce: every n x n block becomes one gray value that is the proportion of
+// unset (white) pixels in the block. Partial blocks on the right and bottom edges are averaged over
+// the pixels they contain.
+func Reduce(width, height, n int, set func(x, y int) bool) [][]uint8 {
+	reduced := NewChannel((width+n-1)/n, (height+n-1)/n)
+	for by, row := range reduced {
+		for bx := range row {
+			white, total := 0, 0
+			for y := by * n; y < (by+1)*n && y < height; y++ {
+				for x := bx * n; x < (bx+1)*n && x < width; x++ {
+					if !set(x, y) {
+						white++
+					}
+					total++
+				}
+			}
+			row[bx] = uint8((white*255 + total/2) / total)
+		}
+	}
+	return reduced
+}
@@ -0,0 +1,157 @@
+package raster
+
+import "math"
+
+// Filter selects the resampling kernel used to resize an image.
+type Filter int
+
+const (
+	// NearestFilter copies the closest source pixel.
+	NearestFilter Filter = iota
+	// BilinearFilter uses a triangle kernel.
+	BilinearFilter
+	// BicubicFilter uses the Catmull-Rom cubic kernel.
+	BicubicFilter
+	// LanczosFilter uses a three-lobed Lanczos windowed sinc kernel.
+	LanczosFilter
+	// AreaFilter averages the source pixels covered by each destination pixel, best for downscaling.
+	AreaFilter
+)
+
+// lanczosWeight is the Lanczos kernel with three lobes.
+func lanczosWeight(t float64) float64 {
+	const lobes = 3
+	t = math.Abs(t)
+	if t == 0 {
+		return 1
+	}
+	if t >= lobes {
+		return 0
+	}
+	x := math.Pi * t
+	return lobes * math.Sin(x) * math.Sin(x/lobes) / (x * x)
+}
+
+// contribution is the weight of one source pixel in a destination pixel.
+type contribution struct {
+	index  int
+	weight float64
+}
+
+// resampleWeights returns, for every destination index, the normalized weights of the source indices.
+func resampleWeights(srcSize, dstSize int, filter Filter) [][]contribution {
+	scale := float64(srcSize) / float64(dstSize)
+	weights := make([][]contribution, dstSize)
+
+	for i := 0; i < dstSize; i++ {
+		switch filter {
+		case NearestFilter:
+			index := ClampInt(int(math.Floor((float64(i)+0.5)*scale)), 0, srcSize-1)
+			weights[i] = []contribution{{index, 1}}
+			continue
+		case AreaFilter:
+			weights[i] = areaWeights(i, srcSize, scale)
+			continue
+		}
+
+		var radius float64
+		var kernel func(float64) float64
+		switch filter {
+		case BilinearFilter:
+			radius, kernel = 1, linearWeight
+		case BicubicFilter:
+			radius, kernel = 2, cubicWeight
+		default:
+			radius, kernel = 3, lanczosWeight
+		}
+		// Stretch the kernel when downscaling so that it also acts as a low-pass filter.
+		filterScale := math.Max(scale, 1)
+		center := (float64(i)+0.5)*scale - 0.5
+		support := radius * filterScale
+
+		var total float64
+		for j := int(math.Ceil(center - support)); j <= int(math.Floor(center+support)); j++ {
+			w := kernel((float64(j) - center) / filterScale)
+			if w == 0 {
+				continue
+			}
+			weights[i] = append(weights[i], contribution{ClampInt(j, 0, srcSize-1), w})
+			total += w
+		}
+		for k := range weights[i] {
+			weights[i][k].weight /= total
+		}
+	}
+	return weights
+}
+
+// areaWeights weights source pixels by how much of them destination pixel i covers.
+func areaWeights(i, srcSize int, scale float64) []contribution {
+	start, end := float64(i)*scale, float64(i+1)*scale
+	var weights []contribution
+	for j := int(math.Floor(start)); j < srcSize && float64(j) < end; j++ {
+		overlap := math.Min(end, float64(j+1)) - math.Max(start, float64(j))
+		if overlap > 0 {
+			weights = append(weights, contribution{j, overlap / scale})
+		}
+	}
+	return weights
+}
+
+// Resize scales the channels of a non-empty image with values up to max to width x height
+// with the given resampling filter. Rows are resampled horizontally, then columns vertically, in parallel.
+func Resize(channels [][][]uint8, width, height int, filter Filter, max int) [][][]uint8 {
+	sourceWidth, sourceHeight := ChannelSize(channels[0])
+	xWeights := resampleWeights(sourceWidth, width, filter)
+	yWeights := resampleWeights(sourceHeight, height, filter)
+
+	results := make([][][]uint8, len(channels))
+	for c, channel := range channels {
+		horizontal := make([][]float64, sourceHeight)
+		ParallelRows(sourceHeight, func(start, end int) {
+			for y := start; y < end; y++ {
+				row := make([]float64, width)
+				for x, contributions := range xWeights {
+					var sum float64
+					for _, w := range contributions {
+						sum += w.weight * float64(channel[y][w.index])
+					}
+					row[x] = sum
+				}
+				horizontal[y] = row
+			}
+		})
+
+		result := NewChannel(width, height)
+		ParallelRows(height, func(start, end int) {
+			for y := start; y < end; y++ {
+				for x := 0; x < width; x++ {
+					var sum float64
+					for _, w := range yWeights[y] {
+						sum += w.weight * horizontal[w.index][x]
+					}
+					result[y][x] = ClampValue(sum, max)
+				}
+			}
+		})
+		results[c] = result
+	}
+	return results
+}
+
+// FitSize scales width x height to fit (or, with cover, to fill) a boxWidth x boxHeight box,
+// preserving the aspect ratio.
+func FitSize(width, height, boxWidth, boxHeight int, cover bool) (int, int) {
+	scaleX := float64(boxWidth) / float64(width)
+	scaleY := float64(boxHeight) / float64(height)
+	scale := math.Min(scaleX, scaleY)
+	if cover {
+		scale = math.Max(scaleX, scaleY)
+	}
+	newWidth := int(math.Round(float64(width) * scale))
+	newHeight := int(math.Round(float64(height) * scale))
+	if cover {
+		newWidth, newHeight = max(newWidth, boxWidth), max(newHeight, boxHeight)
+	}
+	return max(newWidth, 1), max(newHeight, 1)
+}
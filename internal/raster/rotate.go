@@ -0,0 +1,33 @@
+package raster
+
+import "math"
+
+// Rotation returns the size of a width x height image rotated by angle degrees counterclockwise
+// around its center, and the mapping from its pixels back to the source pixels for Warp.
+// When expand is true the canvas grows to hold the whole rotated image, otherwise the size is kept.
+func Rotation(width, height int, angle float64, expand bool) (int, int, func(x, y float64) (float64, float64, bool)) {
+	radians := angle * math.Pi / 180
+	cos, sin := math.Cos(radians), math.Sin(radians)
+
+	newWidth, newHeight := width, height
+	if expand {
+		newWidth, newHeight = RotatedSize(width, height, cos, sin)
+	}
+
+	cx, cy := float64(width-1)/2, float64(height-1)/2
+	ncx, ncy := float64(newWidth-1)/2, float64(newHeight-1)/2
+	inverse := func(x, y float64) (float64, float64, bool) {
+		dx, dy := x-ncx, y-ncy
+		return cx + dx*cos - dy*sin, cy + dx*sin + dy*cos, true
+	}
+	return newWidth, newHeight, inverse
+}
+
+// RotatedSize returns the size of the bounding box of a width x height image rotated by an angle.
+func RotatedSize(width, height int, cos, sin float64) (int, int) {
+	cos, sin = math.Abs(cos), math.Abs(sin)
+	w := float64(width)*cos + float64(height)*sin
+	h := float64(width)*sin + float64(height)*cos
+	// Absorb floating point noise so that right angles give exact sizes.
+	return int(math.Ceil(w - 1e-6)), int(math.Ceil(h - 1e-6))
+}
@@ -0,0 +1,161 @@
+package raster
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// checkToneMax validates the max value of a lookup table, which must fit a uint8 entry.
+func checkToneMax(max int) error {
+	if max < 1 || max > 255 {
+		return fmt.Errorf("invalid max value: %d", max)
+	}
+	return nil
+}
+
+// toneLUT tabulates a tone curve mapping values in [0, max] to values rounded and clamped to [0, max].
+func toneLUT(max int, curve func(v float64) float64) []uint8 {
+	lut := make([]uint8, max+1)
+	for v := range lut {
+		lut[v] = ClampValue(curve(float64(v)), max)
+	}
+	return lut
+}
+
+// GammaLUT returns the lookup table of a gamma correction for values up to max:
+// v becomes max * (v/max)^(1/gamma), so a gamma above 1 brightens the midtones and below 1 darkens them.
+// The max value must be from 1 to 255.
+func GammaLUT(max int, gamma float64) ([]uint8, error) {
+	if err := checkToneMax(max); err != nil {
+		return nil, err
+	}
+	if gamma <= 0 || math.IsNaN(gamma) || math.IsInf(gamma, 0) {
+		return nil, fmt.Errorf("invalid gamma: %v", gamma)
+	}
+	return toneLUT(max, func(v float64) float64 {
+		return float64(max) * math.Pow(v/float64(max), 1/gamma)
+	}), nil
+}
+
+// BrightnessContrastLUT returns the lookup table adjusting brightness and contrast for values up to max.
+// Both range from -1 to 1. Brightness shifts values by a fraction of max. Contrast scales values around
+// the middle of the range: -1 gives a flat gray, 0 changes nothing and 1 gives a threshold.
+// The max value must be from 1 to 255.
+func BrightnessContrastLUT(max int, brightness, contrast float64) ([]uint8, error) {
+	if err := checkToneMax(max); err != nil {
+		return nil, err
+	}
+	if brightness < -1 || brightness > 1 || math.IsNaN(brightness) {
+		return nil, fmt.Errorf("invalid brightness: %v", brightness)
+	}
+	if contrast < -1 || contrast > 1 || math.IsNaN(contrast) {
+		return nil, fmt.Errorf("invalid contrast: %v", contrast)
+	}
+	slope := math.Tan((contrast + 1) * math.Pi / 4)
+	middle := float64(max) / 2
+	return toneLUT(max, func(v float64) float64 {
+		return (v-middle)*slope + middle + brightness*float64(max)
+	}), nil
+}
+
+// LevelsLUT returns the lookup table of a levels adjustment for values up to max: values from inBlack
+// to inWhite are stretched to the [0, 1] range and clipped outside of it, raised to 1/gamma,
+// then spread from outBlack to outWhite. outBlack may be above outWhite to invert the image.
+// The max value must be from 1 to 255.
+func LevelsLUT(max, inBlack, inWhite int, gamma float64, outBlack, outWhite int) ([]uint8, error) {
+	if err := checkToneMax(max); err != nil {
+		return nil, err
+	}
+	if inBlack < 0 || inWhite > max || inBlack >= inWhite {
+		return nil, fmt.Errorf("invalid input levels: %d to %d", inBlack, inWhite)
+	}
+	if outBlack < 0 || outBlack > max || outWhite < 0 || outWhite > max {
+		return nil, fmt.Errorf("invalid output levels: %d to %d", outBlack, outWhite)
+	}
+	if gamma <= 0 || math.IsNaN(gamma) || math.IsInf(gamma, 0) {
+		return nil, fmt.Errorf("invalid gamma: %v", gamma)
+	}
+	return toneLUT(max, func(v float64) float64 {
+		t := math.Max(0, math.Min(1, (v-float64(inBlack))/float64(inWhite-inBlack)))
+		return float64(outBlack) + float64(outWhite-outBlack)*math.Pow(t, 1/gamma)
+	}), nil
+}
+
+// CurveLUT returns the lookup table of a curve for values up to max. Each control point maps an input
+// value X to an output value Y, both from 0 to max. The curve is a monotone cubic spline through the
+// points, so it does not overshoot between them, and stays flat before the first point and after the last.
+// The max value must be from 1 to 255.
+func CurveLUT(max int, points []PointF) ([]uint8, error) {
+	if err := checkToneMax(max); err != nil {
+		return nil, err
+	}
+	if len(points) < 2 {
+		return nil, errors.New("a curve needs at least two control points")
+	}
+	sorted := append([]PointF(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].X < sorted[j].X
+	})
+	for i, p := range sorted {
+		if p.X < 0 || p.X > float64(max) || p.Y < 0 || p.Y > float64(max) {
+			return nil, fmt.Errorf("control point %v out of the [0, %d] range", p, max)
+		}
+		if i > 0 && p.X == sorted[i-1].X {
+			return nil, fmt.Errorf("two control points at input %v", p.X)
+		}
+	}
+
+	tangents := monotoneTangents(sorted)
+	return toneLUT(max, func(v float64) float64 {
+		last := len(sorted) - 1
+		if v <= sorted[0].X {
+			return sorted[0].Y
+		}
+		if v >= sorted[last].X {
+			return sorted[last].Y
+		}
+		i := sort.Search(last, func(i int) bool {
+			return sorted[i+1].X >= v
+		})
+		a, b := sorted[i], sorted[i+1]
+		h := b.X - a.X
+		t := (v - a.X) / h
+		// Cubic Hermite basis.
+		h00 := 2*t*t*t - 3*t*t + 1
+		h10 := t*t*t - 2*t*t + t
+		h01 := -2*t*t*t + 3*t*t
+		h11 := t*t*t - t*t
+		return h00*a.Y + h10*h*tangents[i] + h01*b.Y + h11*h*tangents[i+1]
+	}), nil
+}
+
+// monotoneTangents returns the slopes of the spline at the control points, with the Fritsch-Carlson
+// method: slopes are flattened at local extrema and limited so that every piece stays monotone.
+func monotoneTangents(points []PointF) []float64 {
+	n := len(points)
+	secants := make([]float64, n-1)
+	for i := range secants {
+		secants[i] = (points[i+1].Y - points[i].Y) / (points[i+1].X - points[i].X)
+	}
+	tangents := make([]float64, n)
+	tangents[0], tangents[n-1] = secants[0], secants[n-2]
+	for i := 1; i < n-1; i++ {
+		if secants[i-1]*secants[i] > 0 {
+			tangents[i] = (secants[i-1] + secants[i]) / 2
+		}
+	}
+	for i, s := range secants {
+		if s == 0 {
+			tangents[i], tangents[i+1] = 0, 0
+			continue
+		}
+		alpha, beta := tangents[i]/s, tangents[i+1]/s
+		if r := alpha*alpha + beta*beta; r > 9 {
+			k := 3 / math.Sqrt(r)
+			tangents[i], tangents[i+1] = k*alpha*s, k*beta*s
+		}
+	}
+	return tangents
+}
@@ -0,0 +1,42 @@
+package raster
+
+import "testing"
+
+func TestToneLUTMax(t *testing.T) {
+	builders := map[string]func(max int) ([]uint8, error){
+		"Gamma": func(max int) ([]uint8, error) {
+			return GammaLUT(max, 1)
+		},
+		"BrightnessContrast": func(max int) ([]uint8, error) {
+			return BrightnessContrastLUT(max, 0, 0)
+		},
+		"Levels": func(max int) ([]uint8, error) {
+			return LevelsLUT(max, 0, max, 1, 0, max)
+		},
+		"Curve": func(max int) ([]uint8, error) {
+			return CurveLUT(max, []PointF{{X: 0, Y: 0}, {X: float64(max), Y: float64(max)}})
+		},
+	}
+	for name, build := range builders {
+		for _, max := range []int{-3, 0, 256, 300} {
+			if _, err := build(max); err == nil {
+				t.Errorf("%s: expected an error for a max value of %d", name, max)
+			}
+		}
+		for _, max := range []int{1, 255} {
+			lut, err := build(max)
+			if err != nil {
+				t.Errorf("%s: max value %d: %v", name, max, err)
+				continue
+			}
+			if len(lut) != max+1 {
+				t.Errorf("%s: %d entries for a max value of %d", name, len(lut), max)
+			}
+			for v, out := range lut {
+				if int(out) != v {
+					t.Errorf("%s: identity maps %d to %d for a max value of %d", name, v, out, max)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package raster
+
+import (
+	"errors"
+	"math"
+)
+
+// PointF represents a point with sub-pixel coordinates.
+type PointF struct {
+	X, Y float64
+}
+
+// AffineMatrix maps a point (x, y) to (A*x + B*y + C, D*x + E*y + F).
+type AffineMatrix struct {
+	A, B, C float64
+	D, E, F float64
+}
+
+// IdentityMatrix returns the affine matrix that leaves points unchanged.
+func IdentityMatrix() AffineMatrix {
+	return AffineMatrix{A: 1, E: 1}
+}
+
+// TranslationMatrix returns the affine matrix that moves points by (tx, ty).
+func TranslationMatrix(tx, ty float64) AffineMatrix {
+	return AffineMatrix{A: 1, C: tx, E: 1, F: ty}
+}
+
+// ScaleMatrix returns the affine matrix that scales points by (sx, sy) around the origin.
+func ScaleMatrix(sx, sy float64) AffineMatrix {
+	return AffineMatrix{A: sx, E: sy}
+}
+
+// RotationMatrix returns the affine matrix that rotates points by angle degrees
+// counterclockwise (as seen on screen, y pointing down) around the origin.
+func RotationMatrix(angle float64) AffineMatrix {
+	radians := angle * math.Pi / 180
+	cos, sin := math.Cos(radians), math.Sin(radians)
+	return AffineMatrix{A: cos, B: sin, D: -sin, E: cos}
+}
+
+// ShearMatrix returns the affine matrix that shears points by kx horizontally and ky vertically.
+func ShearMatrix(kx, ky float64) AffineMatrix {
+	return AffineMatrix{A: 1, B: kx, D: ky, E: 1}
+}
+
+// Multiply returns the matrix applying n first, then m.
+func (m AffineMatrix) Multiply(n AffineMatrix) AffineMatrix {
+	return AffineMatrix{
+		A: m.A*n.A + m.B*n.D,
+		B: m.A*n.B + m.B*n.E,
+		C: m.A*n.C + m.B*n.F + m.C,
+		D: m.D*n.A + m.E*n.D,
+		E: m.D*n.B + m.E*n.E,
+		F: m.D*n.C + m.E*n.F + m.F,
+	}
+}
+
+// Invert returns the matrix undoing m, or an error if m is singular.
+func (m AffineMatrix) Invert() (AffineMatrix, error) {
+	det := m.A*m.E - m.B*m.D
+	if math.Abs(det) < 1e-12 {
+		return AffineMatrix{}, errors.New("singular affine matrix")
+	}
+	return AffineMatrix{
+		A: m.E / det,
+		B: -m.B / det,
+		C: (m.B*m.F - m.E*m.C) / det,
+		D: -m.D / det,
+		E: m.A / det,
+		F: (m.D*m.C - m.A*m.F) / det,
+	}, nil
+}
+
+// Apply returns the image of point p by m.
+func (m AffineMatrix) Apply(p PointF) PointF {
+	return PointF{m.A*p.X + m.B*p.Y + m.C, m.D*p.X + m.E*p.Y + m.F}
+}
+
+// Perspective returns the mapping for Warp that takes each of the four dst corners back to the
+// matching src corner. Points on the far side of the horizon of the projection have no source.
+func Perspective(src, dst [4]PointF) (func(x, y float64) (float64, float64, bool), error) {
+	// The homography goes from destination to source, as Warp samples backwards.
+	h, err := homography(dst, src)
+	if err != nil {
+		return nil, err
+	}
+	// The denominator w keeps one sign over the destination quad and changes sign at the horizon,
+	// so take the side of the quad from its centroid rather than assuming w is positive there.
+	var cx, cy float64
+	for _, p := range dst {
+		cx, cy = cx+p.X/4, cy+p.Y/4
+	}
+	side := 1.0
+	if h[6]*cx+h[7]*cy+1 < 0 {
+		side = -1
+	}
+	return func(x, y float64) (float64, float64, bool) {
+		w := h[6]*x + h[7]*y + 1
+		if w*side <= 1e-12 {
+			return 0, 0, false
+		}
+		return (h[0]*x + h[1]*y + h[2]) / w, (h[3]*x + h[4]*y + h[5]) / w, true
+	}, nil
+}
+
+// homography returns the eight coefficients of the projective transform mapping from onto to.
+func homography(from, to [4]PointF) ([8]float64, error) {
+	var system [8][9]float64
+	for i := 0; i < 4; i++ {
+		x, y, u, v := from[i].X, from[i].Y, to[i].X, to[i].Y
+		system[2*i] = [9]float64{x, y, 1, 0, 0, 0, -u * x, -u * y, u}
+		system[2*i+1] = [9]float64{0, 0, 0, x, y, 1, -v * x, -v * y, v}
+	}
+
+	// Gaussian elimination with partial pivoting.
+	for col := 0; col < 8; col++ {
+		pivot := col
+		for row := col + 1; row < 8; row++ {
+			if math.Abs(system[row][col]) > math.Abs(system[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(system[pivot][col]) < 1e-12 {
+			return [8]float64{}, errors.New("degenerate corner points")
+		}
+		system[col], system[pivot] = system[pivot], system[col]
+		for row := 0; row < 8; row++ {
+			if row == col {
+				continue
+			}
+			factor := system[row][col] / system[col][col]
+			for k := col; k < 9; k++ {
+				system[row][k] -= factor * system[col][k]
+			}
+		}
+	}
+
+	var h [8]float64
+	for i := range h {
+		h[i] = system[i][8] / system[i][i]
+	}
+	return h, nil
+}
@@ -0,0 +1,163 @@
+package Netpbm
+
+import "math"
+
+// Connectivity selects which neighbors of a pixel belong to the same component.
+type Connectivity int
+
+const (
+	// FourConnected joins pixels sharing a side.
+	FourConnected Connectivity = iota
+	// EightConnected joins pixels sharing a side or a corner.
+	EightConnected
+)
+
+// Moments holds the raw and central moments of a component, up to the second order.
+// Raw moment Mpq sums x^p * y^q over the pixels; central moments Mupq are taken around the centroid.
+type Moments struct {
+	M00, M10, M01, M20, M11, M02 float64
+	Mu20, Mu11, Mu02             float64
+}
+
+// Component describes a connected group of set pixels.
+type Component struct {
+	// Label is the value of the component's pixels in the label map, starting at 1.
+	Label int
+	// Area is the number of pixels.
+	Area int
+	// Bounds is the smallest rectangle holding the pixels.
+	Bounds Rect
+	// CentroidX and CentroidY are the mean pixel coordinates.
+	CentroidX, CentroidY float64
+	// Perimeter is the number of pixel sides bordering an unset pixel or the border of the image.
+	Perimeter int
+	// Moments are the moments of the pixel coordinates.
+	Moments Moments
+	// Orientation is the angle in degrees of the major axis, counterclockwise from the x axis
+	// as seen on screen, in (-90, 90].
+	Orientation float64
+}
+
+// neighborOffsets returns the offsets of the neighbors for the connectivity.
+func neighborOffsets(connectivity Connectivity) [][2]int {
+	if connectivity == EightConnected {
+		return [][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+	}
+	return [][2]int{{0, -1}, {-1, 0}, {1, 0}, {0, 1}}
+}
+
+// Label finds the connected components of set pixels in the PBM image.
+// It returns a label map, holding for every pixel the label of its component or 0 for unset pixels,
+// and the statistics of every component in label order.
+func (pbm *PBM) Label(connectivity Connectivity) ([][]int, []Component) {
+	labels, pixels := pbm.label(true, connectivity)
+	components := make([]Component, len(pixels))
+	for i, component := range pixels {
+		components[i] = pbm.measure(i+1, component)
+	}
+	return labels, components
+}
+
+// label flood fills the pixels equal to value and returns the label map and the pixels of every component.
+func (pbm *PBM) label(value bool, connectivity Connectivity) ([][]int, [][][2]int) {
+	offsets := neighborOffsets(connectivity)
+	labels := make([][]int, pbm.height)
+	for y := range labels {
+		labels[y] = make([]int, pbm.width)
+	}
+
+	var components [][][2]int
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] != value || labels[y][x] != 0 {
+				continue
+			}
+			label := len(components) + 1
+			labels[y][x] = label
+			component := [][2]int{{x, y}}
+			for i := 0; i < len(component); i++ {
+				p := component[i]
+				for _, o := range offsets {
+					nx, ny := p[0]+o[0], p[1]+o[1]
+					if nx < 0 || ny < 0 || nx >= pbm.width || ny >= pbm.height {
+						continue
+					}
+					if pbm.data[ny][nx] == value && labels[ny][nx] == 0 {
+						labels[ny][nx] = label
+						component = append(component, [2]int{nx, ny})
+					}
+				}
+			}
+			components = append(components, component)
+		}
+	}
+	return labels, components
+}
+
+// measure computes the statistics of a component from its pixels.
+func (pbm *PBM) measure(label int, pixels [][2]int) Component {
+	c := Component{Label: label, Area: len(pixels)}
+	minX, minY, maxX, maxY := pbm.width, pbm.height, -1, -1
+	m := &c.Moments
+	for _, p := range pixels {
+		x, y := float64(p[0]), float64(p[1])
+		m.M00++
+		m.M10 += x
+		m.M01 += y
+		m.M20 += x * x
+		m.M11 += x * y
+		m.M02 += y * y
+
+		minX, maxX = min(minX, p[0]), max(maxX, p[0])
+		minY, maxY = min(minY, p[1]), max(maxY, p[1])
+		for _, o := range neighborOffsets(FourConnected) {
+			if !pbm.get(p[0]+o[0], p[1]+o[1], false) {
+				c.Perimeter++
+			}
+		}
+	}
+
+	c.Bounds = Rect{X: minX, Y: minY, Width: maxX - minX + 1, Height: maxY - minY + 1}
+	c.CentroidX, c.CentroidY = m.M10/m.M00, m.M01/m.M00
+	m.Mu20 = m.M20 - c.CentroidX*m.M10
+	m.Mu11 = m.M11 - c.CentroidX*m.M01
+	m.Mu02 = m.M02 - c.CentroidY*m.M01
+
+	// The y axis points down, so the angle is negated to turn counterclockwise on screen.
+	c.Orientation = -0.5 * math.Atan2(2*m.Mu11, m.Mu20-m.Mu02) * 180 / math.Pi
+	if c.Orientation <= -90 {
+		c.Orientation += 180
+	}
+	return c
+}
+
+// RemoveSmallComponents unsets the connected components of the PBM image with fewer than minArea pixels.
+// It returns the number of components removed.
+func (pbm *PBM) RemoveSmallComponents(minArea int, connectivity Connectivity) int {
+	return pbm.removeSmall(true, minArea, connectivity)
+}
+
+// removeSmall flips the components of pixels equal to value with fewer than minArea pixels.
+func (pbm *PBM) removeSmall(value bool, minArea int, connectivity Connectivity) int {
+	_, components := pbm.label(value, connectivity)
+	removed := 0
+	for _, component := range components {
+		if len(component) >= minArea {
+			continue
+		}
+		for _, p := range component {
+			pbm.data[p[1]][p[0]] = !value
+		}
+		removed++
+	}
+	return removed
+}
+
+// Despeckle cleans the PBM image like pbmclean: black specks (8-connected groups of set pixels)
+// with fewer than minSize pixels are erased, then white specks (4-connected groups of unset pixels)
+// with fewer than minSize pixels are filled. Using complementary connectivities keeps thin lines
+// and the holes next to them consistent. It returns the number of specks removed.
+func (pbm *PBM) Despeckle(minSize int) int {
+	removed := pbm.removeSmall(true, minSize, EightConnected)
+	return removed + pbm.removeSmall(false, minSize, FourConnected)
+}
@@ -0,0 +1,92 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLabel(t *testing.T) {
+	pbm := newPBMFromRows(
+		"##....#",
+		"##...#.",
+		"....#..",
+		".......",
+		"###....",
+	)
+
+	labels, components := pbm.Label(EightConnected)
+	if len(components) != 3 {
+		t.Fatalf("Expected 3 components, got %d", len(components))
+	}
+	if labels[0][0] != 1 || labels[1][5] != 2 || labels[4][2] != 3 || labels[3][3] != 0 {
+		t.Errorf("Wrong label map %v", labels)
+	}
+
+	square := components[0]
+	if square.Area != 4 || square.Bounds != (Rect{X: 0, Y: 0, Width: 2, Height: 2}) || square.Perimeter != 8 {
+		t.Errorf("Wrong square statistics %+v", square)
+	}
+	if square.CentroidX != 0.5 || square.CentroidY != 0.5 {
+		t.Errorf("Wrong square centroid (%v, %v)", square.CentroidX, square.CentroidY)
+	}
+	if square.Moments.Mu20 != 1 || square.Moments.Mu02 != 1 || square.Moments.Mu11 != 0 {
+		t.Errorf("Wrong square moments %+v", square.Moments)
+	}
+
+	// The diagonal rises to the right on screen.
+	diagonal := components[1]
+	if diagonal.Area != 3 || diagonal.Bounds != (Rect{X: 4, Y: 0, Width: 3, Height: 3}) || diagonal.Perimeter != 12 {
+		t.Errorf("Wrong diagonal statistics %+v", diagonal)
+	}
+	if math.Abs(diagonal.Orientation-45) > 1e-9 {
+		t.Errorf("Wrong diagonal orientation %v", diagonal.Orientation)
+	}
+
+	bar := components[2]
+	if bar.Area != 3 || bar.CentroidX != 1 || bar.CentroidY != 4 || bar.Orientation != 0 {
+		t.Errorf("Wrong bar statistics %+v", bar)
+	}
+
+	// Without corners the diagonal falls apart.
+	if _, components := pbm.Label(FourConnected); len(components) != 5 {
+		t.Errorf("Expected 5 four-connected components, got %d", len(components))
+	}
+}
+
+func TestRemoveSmallComponents(t *testing.T) {
+	pbm := newPBMFromRows(
+		"##..#",
+		"##...",
+		"...##",
+	)
+	if removed := pbm.RemoveSmallComponents(3, EightConnected); removed != 2 {
+		t.Errorf("Expected 2 components removed, got %d", removed)
+	}
+	checkRows(t, "RemoveSmallComponents", pbm,
+		"##...",
+		"##...",
+		".....",
+	)
+}
+
+func TestDespeckle(t *testing.T) {
+	pbm := newPBMFromRows(
+		"......",
+		".####.",
+		".#.##.",
+		".####.",
+		"......",
+		"....#.",
+	)
+	if removed := pbm.Despeckle(2); removed != 2 {
+		t.Errorf("Expected 2 specks removed, got %d", removed)
+	}
+	checkRows(t, "Despeckle", pbm,
+		"......",
+		".####.",
+		".####.",
+		".####.",
+		"......",
+		"......",
+	)
+}
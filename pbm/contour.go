@@ -0,0 +1,188 @@
+package Netpbm
+
+import "math"
+
+// PointF represents a point with sub-pixel coordinates.
+type PointF struct {
+	X, Y float64
+}
+
+// Polygon is a closed outline given by its corners; the last corner joins the first one.
+type Polygon []PointF
+
+// Shape is a connected group of set pixels outlined by polygons.
+// Outer runs clockwise on screen, and each hole runs counterclockwise.
+type Shape struct {
+	Outer Polygon
+	Holes []Polygon
+}
+
+// Directions of the boundary edges: right, down, left, up.
+var (
+	directionX = [4]int{1, 0, -1, 0}
+	directionY = [4]int{0, 1, 0, -1}
+)
+
+// TraceContours follows the boundaries between set and unset pixels of the PBM image and returns
+// one shape per 8-connected group of set pixels, in the order of Label.
+// Corners lie on the pixel grid: the pixel (x, y) covers the square from (x, y) to (x+1, y+1).
+// Set pixels touching by a corner belong to the same outline.
+func (pbm *PBM) TraceContours() []Shape {
+	labels, components := pbm.label(true, EightConnected)
+	shapes := make([]Shape, len(components))
+
+	// Every side of a set pixel facing an unset pixel is an edge, oriented so that the pixel lies on its right.
+	stride := pbm.width + 1
+	edges := make([]uint8, stride*(pbm.height+1))
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if !pbm.data[y][x] {
+				continue
+			}
+			if !pbm.get(x, y-1, false) {
+				edges[y*stride+x] |= 1 << 0
+			}
+			if !pbm.get(x+1, y, false) {
+				edges[y*stride+x+1] |= 1 << 1
+			}
+			if !pbm.get(x, y+1, false) {
+				edges[(y+1)*stride+x+1] |= 1 << 2
+			}
+			if !pbm.get(x-1, y, false) {
+				edges[(y+1)*stride+x] |= 1 << 3
+			}
+		}
+	}
+
+	for v := range edges {
+		for edges[v] != 0 {
+			startX, startY := v%stride, v/stride
+			start := 0
+			for edges[v]&(1<<start) == 0 {
+				start++
+			}
+			polygon := traceEdges(edges, stride, startX, startY, start)
+
+			// The pixel on the right of the first edge tells which shape the outline belongs to.
+			px, py := startX, startY
+			switch start {
+			case 1:
+				px--
+			case 2:
+				px, py = px-1, py-1
+			case 3:
+				py--
+			}
+			shape := &shapes[labels[py][px]-1]
+			if polygon.area() > 0 {
+				shape.Outer = polygon
+			} else {
+				shape.Holes = append(shape.Holes, polygon)
+			}
+		}
+	}
+	return shapes
+}
+
+// traceEdges follows the edges from a vertex until it comes back to the starting edge,
+// consuming them and returning the corners met on the way.
+// Where two edges leave a vertex, the left turn is taken so that diagonal set pixels stay joined.
+func traceEdges(edges []uint8, stride, startX, startY, start int) Polygon {
+	var polygon Polygon
+	x, y, direction := startX, startY, start
+	for {
+		edges[y*stride+x] &^= 1 << direction
+		x, y = x+directionX[direction], y+directionY[direction]
+
+		available := edges[y*stride+x]
+		if x == startX && y == startY {
+			available |= 1 << start
+		}
+		next := direction
+		for _, turn := range []int{3, 0, 1} {
+			if available&(1<<((direction+turn)%4)) != 0 {
+				next = (direction + turn) % 4
+				break
+			}
+		}
+		if next != direction {
+			polygon = append(polygon, PointF{float64(x), float64(y)})
+		}
+		if x == startX && y == startY && next == start {
+			return polygon
+		}
+		direction = next
+	}
+}
+
+// area returns the signed area of the polygon, positive when it runs clockwise on screen.
+func (polygon Polygon) area() float64 {
+	var sum float64
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		sum += p.X*q.Y - q.X*p.Y
+	}
+	return sum / 2
+}
+
+// Simplify reduces the number of corners of the polygon with the Douglas-Peucker algorithm:
+// corners closer than tolerance to the line joining the corners kept around them are dropped.
+// The polygon is returned unchanged when simplifying would leave fewer than three corners.
+func (polygon Polygon) Simplify(tolerance float64) Polygon {
+	n := len(polygon)
+	if n < 4 || tolerance <= 0 {
+		return append(Polygon(nil), polygon...)
+	}
+
+	// Split the closed outline at the corner farthest from the first one.
+	far, farthest := 0, -1.0
+	for i, p := range polygon {
+		if d := math.Hypot(p.X-polygon[0].X, p.Y-polygon[0].Y); d > farthest {
+			far, farthest = i, d
+		}
+	}
+	keep := make([]bool, n)
+	keep[0], keep[far] = true, true
+	polygon.simplifyRange(0, far, tolerance, keep)
+	polygon.simplifyRange(far, n, tolerance, keep)
+
+	var simplified Polygon
+	for i, p := range polygon {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	if len(simplified) < 3 {
+		return append(Polygon(nil), polygon...)
+	}
+	return simplified
+}
+
+// simplifyRange marks the corners to keep strictly between first and last; last may equal the length
+// of the polygon to stand for its first corner.
+func (polygon Polygon) simplifyRange(first, last int, tolerance float64, keep []bool) {
+	a, b := polygon[first], polygon[last%len(polygon)]
+	index, distance := -1, tolerance
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(polygon[i], a, b); d > distance {
+			index, distance = i, d
+		}
+	}
+	if index < 0 {
+		return
+	}
+	keep[index] = true
+	polygon.simplifyRange(first, index, tolerance, keep)
+	polygon.simplifyRange(index, last, tolerance, keep)
+}
+
+// segmentDistance returns the distance from p to the segment from a to b.
+func segmentDistance(p, a, b PointF) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	t := math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/length))
+	return math.Hypot(p.X-a.X-t*dx, p.Y-a.Y-t*dy)
+}
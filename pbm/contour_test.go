@@ -0,0 +1,126 @@
+package Netpbm
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTraceContours(t *testing.T) {
+	pbm := newPBMFromRows(
+		"###...",
+		"#.#..#",
+		"###.#.",
+	)
+	shapes := pbm.TraceContours()
+	if len(shapes) != 2 {
+		t.Fatalf("Expected 2 shapes, got %d", len(shapes))
+	}
+
+	ring := shapes[0]
+	if len(ring.Outer) != 4 || ring.Outer.area() != 9 {
+		t.Errorf("Wrong ring outline %v", ring.Outer)
+	}
+	if len(ring.Holes) != 1 || ring.Holes[0].area() != -1 {
+		t.Errorf("Wrong ring holes %v", ring.Holes)
+	}
+	for _, p := range ring.Outer {
+		if (p.X != 0 && p.X != 3) || (p.Y != 0 && p.Y != 3) {
+			t.Errorf("Unexpected ring corner %v", p)
+		}
+	}
+
+	// Pixels touching by a corner share one outline.
+	diagonal := shapes[1]
+	if len(diagonal.Outer) != 8 || diagonal.Outer.area() != 2 || len(diagonal.Holes) != 0 {
+		t.Errorf("Wrong diagonal outline %v", diagonal.Outer)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	polygon := Polygon{{0, 0}, {2, 0}, {4, 0.1}, {6, 0}, {6, 3}, {3, 3}, {0, 3}, {0, 1}}
+	simplified := polygon.Simplify(0.5)
+	expected := Polygon{{0, 0}, {6, 0}, {6, 3}, {0, 3}}
+	if len(simplified) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, simplified)
+	}
+	for i := range expected {
+		if simplified[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, simplified)
+			break
+		}
+	}
+
+	// A pixel cannot lose corners.
+	pixel := Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	if simplified := pixel.Simplify(10); len(simplified) != 4 {
+		t.Errorf("Expected the pixel unchanged, got %v", simplified)
+	}
+}
+
+func TestFitBezier(t *testing.T) {
+	square := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	sharp := square.FitBezier(0)
+	if sharp.Start != (PointF{0, 1}) || len(sharp.Segments) != 8 {
+		t.Errorf("Wrong sharp path %+v", sharp)
+	}
+	for _, s := range sharp.Segments {
+		if s.Cubic {
+			t.Error("Expected only straight lines")
+		}
+	}
+
+	smooth := square.FitBezier(90)
+	if len(smooth.Segments) != 4 {
+		t.Fatalf("Wrong smooth path %+v", smooth)
+	}
+	first := smooth.Segments[0]
+	if !first.Cubic || first.End != (PointF{1, 0}) {
+		t.Errorf("Wrong first curve %+v", first)
+	}
+	if d := segmentDistance(first.Control1, PointF{0, 1}, PointF{0, 0}); d > 1e-9 {
+		t.Errorf("First control point %v is off the incoming side", first.Control1)
+	}
+}
+
+func TestSaveSVG(t *testing.T) {
+	pbm := newPBMFromRows(
+		"###",
+		"#.#",
+		"###",
+	)
+	var builder strings.Builder
+	shape := pbm.TraceContours()[0]
+	err := WriteSVG(&builder, 3, 3, []Path{shape.Outer.Path(), shape.Holes[0].Path()})
+	if err != nil {
+		t.Error(err)
+	}
+	svg := builder.String()
+	if !strings.Contains(svg, `viewBox="0 0 3 3"`) || strings.Count(svg, "M") != 2 || strings.Count(svg, "Z") != 2 {
+		t.Errorf("Unexpected SVG:\n%s", svg)
+	}
+
+	err = pbm.SaveSVG("testSave.svg", 1, 60)
+	if err != nil {
+		t.Error(err)
+	}
+	data, err := os.ReadFile("testSave.svg")
+	if err != nil {
+		t.Error(err)
+	}
+	if !strings.HasSuffix(string(data), "</svg>\n") {
+		t.Errorf("Unexpected SVG file:\n%s", data)
+	}
+	err = os.Remove("testSave.svg")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSVGNumber(t *testing.T) {
+	for v, expected := range map[float64]string{1: "1", 0.5: "0.5", 2.0 / 3: "0.667", -1e-9: "0"} {
+		if s := svgNumber(v); s != expected {
+			t.Errorf("svgNumber(%v) = %s, expected %s", v, s, expected)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Rect represents a rectangular area of an image.
+type Rect = raster.Rect
+
+// Crop keeps only the area of the PBM image inside rect.
+func (pbm *PBM) Crop(rect Rect) error {
+	newData, err := raster.Crop(pbm.data, pbm.width, pbm.height, rect)
+	if err != nil {
+		return err
+	}
+	pbm.data = newData
+	pbm.width, pbm.height = rect.Width, rect.Height
+	return nil
+}
+
+// AutoCrop trims the borders of the PBM image that have the value of its top-left corner.
+// It returns the area that was kept.
+func (pbm *PBM) AutoCrop() (Rect, error) {
+	if pbm.width == 0 || pbm.height == 0 {
+		return Rect{}, nil
+	}
+	return pbm.AutoCropColor(pbm.data[0][0])
+}
+
+// AutoCropColor trims the borders of the PBM image that have the given value.
+// It returns the area that was kept. An image made only of the border value is left untouched.
+func (pbm *PBM) AutoCropColor(value bool) (Rect, error) {
+	isBorder := func(x, y int) bool {
+		return pbm.data[y][x] == value
+	}
+	rect := Rect{Width: pbm.width, Height: pbm.height}
+	if trimmed, ok := raster.TrimBounds(pbm.width, pbm.height, isBorder); ok {
+		if err := pbm.Crop(trimmed); err != nil {
+			return Rect{}, err
+		}
+		rect = trimmed
+	}
+	return rect, nil
+}
+
+// Pad grows the canvas of the PBM image by the given number of pixels on each side,
+// filling the new area with the fill value.
+func (pbm *PBM) Pad(top, right, bottom, left int, fill bool) error {
+	return pbm.pad(top, right, bottom, left, func(x, y int) bool {
+		return fill
+	})
+}
+
+// PadReplicate grows the canvas of the PBM image by the given number of pixels on each side,
+// extending the edge pixels into the new area.
+func (pbm *PBM) PadReplicate(top, right, bottom, left int) error {
+	if pbm.width == 0 || pbm.height == 0 {
+		return errors.New("cannot replicate the edges of an empty image")
+	}
+	return pbm.pad(top, right, bottom, left, func(x, y int) bool {
+		return pbm.data[raster.ClampInt(y, 0, pbm.height-1)][raster.ClampInt(x, 0, pbm.width-1)]
+	})
+}
+
+// Margin adds a border of the same size on every side of the PBM image.
+func (pbm *PBM) Margin(size int, fill bool) error {
+	return pbm.Pad(size, size, size, size, fill)
+}
+
+// pad grows the canvas, asking outside for the value of every new pixel in source coordinates.
+func (pbm *PBM) pad(top, right, bottom, left int, outside func(x, y int) bool) error {
+	newData, err := raster.Pad(pbm.data, pbm.width, pbm.height, top, right, bottom, left, outside)
+	if err != nil {
+		return err
+	}
+	pbm.data = newData
+	pbm.width, pbm.height = pbm.width+left+right, pbm.height+top+bottom
+	return nil
+}
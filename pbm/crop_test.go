@@ -0,0 +1,70 @@
+package Netpbm
+
+import "testing"
+
+func TestCrop(t *testing.T) {
+	pbm, err := ReadPBM("testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	if err := pbm.Crop(Rect{X: 5, Y: 0, Width: 6, Height: 3}); err != nil {
+		t.Error(err)
+	}
+	if pbm.width != 6 || pbm.height != 3 {
+		t.Errorf("Wrong size %dx%d", pbm.width, pbm.height)
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 6; x++ {
+			if pbm.data[y][x] != imageDataP1[y*imageWidth+x+5] {
+				t.Errorf("Pixel at (%d, %d) not cropped correctly", x, y)
+			}
+		}
+	}
+	if err := pbm.Crop(Rect{X: -1, Y: 0, Width: 2, Height: 2}); err == nil {
+		t.Error("Expected an error for an area out of the image")
+	}
+}
+
+func TestAutoCrop(t *testing.T) {
+	pbm, err := ReadPBM("testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	if err := pbm.Margin(4, false); err != nil {
+		t.Error(err)
+	}
+	rect, err := pbm.AutoCrop()
+	if err != nil {
+		t.Error(err)
+	}
+	if rect != (Rect{X: 4, Y: 4, Width: imageWidth, Height: imageHeight}) {
+		t.Errorf("Wrong trimmed area %v", rect)
+	}
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		if pbm.data[i/imageWidth][i%imageWidth] != imageDataP1[i] {
+			t.Error("Wrong data")
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	pbm := NewPBM(2, 1)
+	pbm.data[0][1] = true
+	if err := pbm.PadReplicate(1, 2, 0, 0); err != nil {
+		t.Error(err)
+	}
+	expected := [][]bool{
+		{false, true, true, true},
+		{false, true, true, true},
+	}
+	for y := range expected {
+		for x := range expected[y] {
+			if pbm.data[y][x] != expected[y][x] {
+				t.Errorf("Pixel at (%d, %d) not padded correctly", x, y)
+			}
+		}
+	}
+	if err := pbm.Pad(1, 1, 1, 1, true); err != nil || pbm.width != 6 || pbm.height != 4 || !pbm.data[0][0] {
+		t.Error("Padding not added correctly")
+	}
+}
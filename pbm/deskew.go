@@ -0,0 +1,89 @@
+package Netpbm
+
+import "math"
+
+// Search steps, in degrees, of the skew detection: a coarse scan followed by a refinement.
+const (
+	skewCoarseStep = 0.5
+	skewFineStep   = 0.05
+)
+
+// DetectSkew estimates the skew of a scanned document in the PBM image, in the manner of pamtilt.
+// Set pixels are taken as ink. Their projection profile is computed
+// for every angle in [-maxAngle, maxAngle] degrees, and the angle giving the sharpest profile wins.
+// The returned angle is counterclockwise, i.e. text lines rise to the right for positive angles.
+// The confidence goes from 0 (no preferred angle) towards 1 (one angle clearly stands out).
+func (pbm *PBM) DetectSkew(maxAngle float64) (angle, confidence float64) {
+	var points [][2]int
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				points = append(points, [2]int{x, y})
+			}
+		}
+	}
+	return estimateSkew(points, maxAngle)
+}
+
+// Deskew detects the skew of the PBM image with DetectSkew and rotates the image to undo it,
+// filling uncovered areas with white. It returns the detected angle and its confidence.
+func (pbm *PBM) Deskew(maxAngle float64) (angle, confidence float64) {
+	angle, confidence = pbm.DetectSkew(maxAngle)
+	if angle != 0 {
+		pbm.Rotate(-angle, false, false)
+	}
+	return angle, confidence
+}
+
+// estimateSkew finds the angle whose projection profile of the points is the sharpest.
+func estimateSkew(points [][2]int, maxAngle float64) (float64, float64) {
+	if len(points) == 0 {
+		return 0, 0
+	}
+	maxAngle = math.Abs(maxAngle)
+
+	best, bestScore := 0.0, -1.0
+	var sum float64
+	count := 0
+	for angle := -maxAngle; angle <= maxAngle+1e-9; angle += skewCoarseStep {
+		score := profileScore(points, angle)
+		sum += score
+		count++
+		if score > bestScore {
+			best, bestScore = angle, score
+		}
+	}
+	mean := sum / float64(count)
+
+	coarse := best
+	for angle := coarse - skewCoarseStep; angle <= coarse+skewCoarseStep+1e-9; angle += skewFineStep {
+		if angle < -maxAngle || angle > maxAngle {
+			continue
+		}
+		if score := profileScore(points, angle); score > bestScore {
+			best, bestScore = angle, score
+		}
+	}
+
+	if bestScore <= 0 {
+		return 0, 0
+	}
+	return math.Round(best/skewFineStep) * skewFineStep, (bestScore - mean) / bestScore
+}
+
+// profileScore projects the points along lines tilted by angle degrees and returns the sum of
+// the squared bin counts, which is largest when the lines follow the rows of ink.
+func profileScore(points [][2]int, angle float64) float64 {
+	radians := angle * math.Pi / 180
+	sin, cos := math.Sin(radians), math.Cos(radians)
+	bins := make(map[int]int)
+	for _, p := range points {
+		r := float64(p[0])*sin + float64(p[1])*cos
+		bins[int(math.Floor(r+0.5))]++
+	}
+	var score float64
+	for _, n := range bins {
+		score += float64(n) * float64(n)
+	}
+	return score
+}
@@ -0,0 +1,58 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+// newTextPBM returns a page with rows of dashes that look like lines of text.
+func newTextPBM() *PBM {
+	pbm := NewPBM(200, 120)
+	for line := 20; line < 100; line += 12 {
+		for x := 20; x < 180; x++ {
+			if x%9 == 8 {
+				continue
+			}
+			pbm.data[line][x] = true
+			pbm.data[line+1][x] = true
+		}
+	}
+	return pbm
+}
+
+func TestDetectSkew(t *testing.T) {
+	pbm := newTextPBM()
+	angle, confidence := pbm.DetectSkew(10)
+	if math.Abs(angle) > 0.01 {
+		t.Errorf("Straight page detected as skewed by %v", angle)
+	}
+
+	pbm.Rotate(3, false, false)
+	angle, confidence = pbm.DetectSkew(10)
+	if math.Abs(angle-3) > 0.3 {
+		t.Errorf("Wrong skew angle, expected about 3, got %v", angle)
+	}
+	if confidence < 0.3 || confidence > 1 {
+		t.Errorf("Wrong confidence %v", confidence)
+	}
+
+	angle, confidence = NewPBM(20, 20).DetectSkew(10)
+	if angle != 0 || confidence != 0 {
+		t.Error("Blank page should give no skew and no confidence")
+	}
+}
+
+func TestDeskew(t *testing.T) {
+	pbm := newTextPBM()
+	pbm.Rotate(-4, false, false)
+	angle, _ := pbm.Deskew(10)
+	if math.Abs(angle+4) > 0.3 {
+		t.Errorf("Wrong skew angle, expected about -4, got %v", angle)
+	}
+	if pbm.width != 200 || pbm.height != 120 {
+		t.Error("Size not kept")
+	}
+	if residual, _ := pbm.DetectSkew(10); math.Abs(residual) > 0.3 {
+		t.Errorf("Page still skewed by %v", residual)
+	}
+}
@@ -0,0 +1,160 @@
+package Netpbm
+
+import "math"
+
+// DistanceMetric selects how distances between pixels are measured.
+type DistanceMetric int
+
+const (
+	// Euclidean is the exact straight-line distance.
+	Euclidean DistanceMetric = iota
+	// Manhattan counts horizontal and vertical steps.
+	Manhattan
+	// Chessboard counts steps in any of the eight directions.
+	Chessboard
+	// Chamfer approximates the Euclidean distance with steps of 1 and 4/3 (the 3-4 chamfer).
+	Chamfer
+)
+
+// DistanceTransform returns for every pixel of the PBM image its distance to the nearest set pixel,
+// between pixel centers; set pixels are at distance 0. Distances are infinite in an image without
+// set pixels. The Euclidean metric is exact and computed with the algorithm of Felzenszwalb and
+// Huttenlocher in time linear in the number of pixels.
+func (pbm *PBM) DistanceTransform(metric DistanceMetric) [][]float64 {
+	return pbm.distanceTo(true, metric)
+}
+
+// distanceTo returns for every pixel its distance to the nearest pixel equal to value.
+func (pbm *PBM) distanceTo(value bool, metric DistanceMetric) [][]float64 {
+	distances := make([][]float64, pbm.height)
+	for y := range distances {
+		distances[y] = make([]float64, pbm.width)
+		for x := range distances[y] {
+			if pbm.data[y][x] != value {
+				distances[y][x] = math.Inf(1)
+			}
+		}
+	}
+
+	switch metric {
+	case Manhattan:
+		chamferDistance(distances, pbm.width, pbm.height, 1, 2)
+	case Chessboard:
+		chamferDistance(distances, pbm.width, pbm.height, 1, 1)
+	case Chamfer:
+		chamferDistance(distances, pbm.width, pbm.height, 1, 4.0/3)
+	default:
+		euclideanDistance(distances, pbm.width, pbm.height)
+	}
+	return distances
+}
+
+// euclideanDistance turns a plane holding 0 on feature pixels and +Inf elsewhere into exact
+// Euclidean distances, with a one-dimensional squared distance transform along columns then rows.
+func euclideanDistance(distances [][]float64, width, height int) {
+	column := make([]float64, height)
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			column[y] = distances[y][x]
+		}
+		squaredDistance1D(column)
+		for y := 0; y < height; y++ {
+			distances[y][x] = column[y]
+		}
+	}
+	for y := 0; y < height; y++ {
+		squaredDistance1D(distances[y])
+		for x := range distances[y] {
+			distances[y][x] = math.Sqrt(distances[y][x])
+		}
+	}
+}
+
+// squaredDistance1D replaces f by its squared distance transform: min over q of (p-q)^2 + f(q).
+// It computes the lower envelope of the parabolas rooted at every finite sample.
+func squaredDistance1D(f []float64) {
+	n := len(f)
+	if n == 0 {
+		return
+	}
+	original := append([]float64(nil), f...)
+	roots := make([]int, 0, n)
+	bounds := make([]float64, 0, n+1)
+	intersection := func(q, r int) float64 {
+		return ((original[q] + float64(q*q)) - (original[r] + float64(r*r))) / float64(2*(q-r))
+	}
+	for q := 0; q < n; q++ {
+		if math.IsInf(original[q], 1) {
+			continue
+		}
+		for len(roots) > 0 {
+			s := intersection(q, roots[len(roots)-1])
+			if s > bounds[len(bounds)-1] {
+				bounds = append(bounds, s)
+				break
+			}
+			roots = roots[:len(roots)-1]
+			bounds = bounds[:len(bounds)-1]
+		}
+		if len(roots) == 0 {
+			bounds = append(bounds, math.Inf(-1))
+		}
+		roots = append(roots, q)
+	}
+	if len(roots) == 0 {
+		return
+	}
+
+	k := 0
+	for p := 0; p < n; p++ {
+		for k+1 < len(roots) && bounds[k+1] < float64(p) {
+			k++
+		}
+		d := float64(p - roots[k])
+		f[p] = d*d + original[roots[k]]
+	}
+}
+
+// chamferDistance turns a plane holding 0 on feature pixels and +Inf elsewhere into chamfer distances
+// with the given costs for orthogonal and diagonal steps, using a forward and a backward raster scan.
+func chamferDistance(distances [][]float64, width, height int, orthogonal, diagonal float64) {
+	relax := func(x, y, nx, ny int, cost float64) {
+		if nx >= 0 && ny >= 0 && nx < width && ny < height && distances[ny][nx]+cost < distances[y][x] {
+			distances[y][x] = distances[ny][nx] + cost
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			relax(x, y, x-1, y, orthogonal)
+			relax(x, y, x, y-1, orthogonal)
+			relax(x, y, x-1, y-1, diagonal)
+			relax(x, y, x+1, y-1, diagonal)
+		}
+	}
+	for y := height - 1; y >= 0; y-- {
+		for x := width - 1; x >= 0; x-- {
+			relax(x, y, x+1, y, orthogonal)
+			relax(x, y, x, y+1, orthogonal)
+			relax(x, y, x+1, y+1, diagonal)
+			relax(x, y, x-1, y+1, diagonal)
+		}
+	}
+}
+
+// SignedDistanceField returns for every pixel of the PBM image its signed distance to the outline of
+// the set pixels: positive outside the shapes, negative inside. The outline runs between pixels,
+// so the pixels on both sides of it are at +0.5 and -0.5.
+func (pbm *PBM) SignedDistanceField(metric DistanceMetric) [][]float64 {
+	outside := pbm.distanceTo(true, metric)
+	inside := pbm.distanceTo(false, metric)
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				outside[y][x] = 0.5 - inside[y][x]
+			} else {
+				outside[y][x] -= 0.5
+			}
+		}
+	}
+	return outside
+}
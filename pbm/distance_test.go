@@ -0,0 +1,80 @@
+package Netpbm
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteDistance measures the distance from (x, y) to the nearest set pixel by trying them all.
+func bruteDistance(pbm *PBM, x, y int, metric DistanceMetric) float64 {
+	best := math.Inf(1)
+	for sy := 0; sy < pbm.height; sy++ {
+		for sx := 0; sx < pbm.width; sx++ {
+			if !pbm.data[sy][sx] {
+				continue
+			}
+			dx, dy := math.Abs(float64(sx-x)), math.Abs(float64(sy-y))
+			d := math.Hypot(dx, dy)
+			switch metric {
+			case Manhattan:
+				d = dx + dy
+			case Chessboard:
+				d = math.Max(dx, dy)
+			}
+			best = math.Min(best, d)
+		}
+	}
+	return best
+}
+
+func TestDistanceTransform(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	pbm := NewPBM(23, 17)
+	for i := 0; i < 12; i++ {
+		pbm.data[random.Intn(pbm.height)][random.Intn(pbm.width)] = true
+	}
+
+	for _, metric := range []DistanceMetric{Euclidean, Manhattan, Chessboard} {
+		distances := pbm.DistanceTransform(metric)
+		for y := 0; y < pbm.height; y++ {
+			for x := 0; x < pbm.width; x++ {
+				if expected := bruteDistance(pbm, x, y, metric); math.Abs(distances[y][x]-expected) > 1e-9 {
+					t.Errorf("Metric %d: got %v at (%d, %d), expected %v", metric, distances[y][x], x, y, expected)
+				}
+			}
+		}
+	}
+
+	// The chamfer distance stays close to the Euclidean one.
+	distances := pbm.DistanceTransform(Chamfer)
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			expected := bruteDistance(pbm, x, y, Euclidean)
+			if math.Abs(distances[y][x]-expected) > expected*0.1 {
+				t.Errorf("Chamfer: got %v at (%d, %d), expected about %v", distances[y][x], x, y, expected)
+			}
+		}
+	}
+
+	if d := NewPBM(3, 2).DistanceTransform(Euclidean); !math.IsInf(d[1][2], 1) {
+		t.Errorf("Expected an infinite distance without set pixels, got %v", d[1][2])
+	}
+}
+
+func TestSignedDistanceField(t *testing.T) {
+	pbm := newPBMFromRows(
+		"........",
+		"..####..",
+		"..####..",
+		"..####..",
+		"..####..",
+		"........",
+	)
+	field := pbm.SignedDistanceField(Euclidean)
+	for x, expected := range []float64{1.5, 0.5, -0.5, -1.5, -1.5, -0.5, 0.5, 1.5} {
+		if field[2][x] != expected {
+			t.Errorf("Got %v at (%d, 2), expected %v", field[2][x], x, expected)
+		}
+	}
+}
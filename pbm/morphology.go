@@ -0,0 +1,309 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// StructuringElement is the shape probing the image in morphological operations.
+// Data holds Height rows of Width cells; set cells belong to the shape.
+// The origin is the cell placed over the pixel being computed.
+type StructuringElement struct {
+	Width, Height    int
+	Data             [][]bool
+	OriginX, OriginY int
+}
+
+// NewStructuringElement creates a structuring element from rows of cells and the position of its origin.
+func NewStructuringElement(data [][]bool, originX, originY int) (StructuringElement, error) {
+	if len(data) == 0 || len(data[0]) == 0 {
+		return StructuringElement{}, errors.New("empty structuring element")
+	}
+	width := len(data[0])
+	for _, row := range data {
+		if len(row) != width {
+			return StructuringElement{}, errors.New("structuring element rows have different lengths")
+		}
+	}
+	if originX < 0 || originY < 0 || originX >= width || originY >= len(data) {
+		return StructuringElement{}, errors.New("structuring element origin out of bounds")
+	}
+	return StructuringElement{Width: width, Height: len(data), Data: data, OriginX: originX, OriginY: originY}, nil
+}
+
+// shapeElement creates a (2*radius+1) square element centered on its origin, keeping the cells accepted by inside.
+func shapeElement(radius int, inside func(dx, dy int) bool) (StructuringElement, error) {
+	if radius < 0 {
+		return StructuringElement{}, fmt.Errorf("invalid radius: %d", radius)
+	}
+	size := 2*radius + 1
+	data := make([][]bool, size)
+	for y := range data {
+		data[y] = make([]bool, size)
+		for x := range data[y] {
+			data[y][x] = inside(x-radius, y-radius)
+		}
+	}
+	return StructuringElement{Width: size, Height: size, Data: data, OriginX: radius, OriginY: radius}, nil
+}
+
+// SquareElement returns a (2*radius+1) square structuring element.
+// The radius must not be negative.
+func SquareElement(radius int) (StructuringElement, error) {
+	return shapeElement(radius, func(dx, dy int) bool {
+		return true
+	})
+}
+
+// CrossElement returns a cross-shaped structuring element with arms of the given radius.
+// The radius must not be negative.
+func CrossElement(radius int) (StructuringElement, error) {
+	return shapeElement(radius, func(dx, dy int) bool {
+		return dx == 0 || dy == 0
+	})
+}
+
+// DiskElement returns a disk-shaped structuring element of the given radius.
+// The radius must not be negative.
+func DiskElement(radius int) (StructuringElement, error) {
+	limit := float64(radius) + 0.5
+	return shapeElement(radius, func(dx, dy int) bool {
+		return math.Hypot(float64(dx), float64(dy)) <= limit
+	})
+}
+
+// Offsets returns the positions of the set cells relative to the origin.
+func (se StructuringElement) Offsets() [][2]int {
+	var offsets [][2]int
+	for y := 0; y < se.Height; y++ {
+		for x := 0; x < se.Width; x++ {
+			if se.Data[y][x] {
+				offsets = append(offsets, [2]int{x - se.OriginX, y - se.OriginY})
+			}
+		}
+	}
+	return offsets
+}
+
+// get returns the pixel at (x, y), or outside for pixels past the border.
+func (pbm *PBM) get(x, y int, outside bool) bool {
+	if x < 0 || y < 0 || x >= pbm.width || y >= pbm.height {
+		return outside
+	}
+	return pbm.data[y][x]
+}
+
+// newData allocates unset pixel rows with the size of the image.
+func (pbm *PBM) newData() [][]bool {
+	data := make([][]bool, pbm.height)
+	for y := range data {
+		data[y] = make([]bool, pbm.width)
+	}
+	return data
+}
+
+// clone returns a copy of the PBM image.
+func (pbm *PBM) clone() *PBM {
+	copied := &PBM{data: pbm.newData(), width: pbm.width, height: pbm.height, magicNumber: pbm.magicNumber}
+	for y := range pbm.data {
+		copy(copied.data[y], pbm.data[y])
+	}
+	return copied
+}
+
+// Erode keeps the set pixels of the PBM image where the whole structuring element fits in the set pixels.
+// Pixels past the border count as set, so shapes touching the border do not shrink from it.
+func (pbm *PBM) Erode(se StructuringElement) {
+	offsets := se.Offsets()
+	newData := pbm.newData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			fits := true
+			for _, o := range offsets {
+				if !pbm.get(x+o[0], y+o[1], true) {
+					fits = false
+					break
+				}
+			}
+			newData[y][x] = fits
+		}
+	}
+	pbm.data = newData
+}
+
+// Dilate sets every pixel of the PBM image reached by the structuring element placed over a set pixel.
+func (pbm *PBM) Dilate(se StructuringElement) {
+	offsets := se.Offsets()
+	newData := pbm.newData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			for _, o := range offsets {
+				// The reflected element: (x, y) is reached from the set pixel (x - dx, y - dy).
+				if pbm.get(x-o[0], y-o[1], false) {
+					newData[y][x] = true
+					break
+				}
+			}
+		}
+	}
+	pbm.data = newData
+}
+
+// Open erodes then dilates the PBM image, removing set details smaller than the structuring element.
+func (pbm *PBM) Open(se StructuringElement) {
+	pbm.Erode(se)
+	pbm.Dilate(se)
+}
+
+// Close dilates then erodes the PBM image, filling unset gaps smaller than the structuring element.
+func (pbm *PBM) Close(se StructuringElement) {
+	pbm.Dilate(se)
+	pbm.Erode(se)
+}
+
+// MorphologicalGradient keeps the outline of the shapes of the PBM image: the dilation minus the erosion.
+func (pbm *PBM) MorphologicalGradient(se StructuringElement) {
+	eroded := pbm.clone()
+	eroded.Erode(se)
+	pbm.Dilate(se)
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			pbm.data[y][x] = pbm.data[y][x] && !eroded.data[y][x]
+		}
+	}
+}
+
+// HitOrMiss keeps the pixels of the PBM image where the hit element fits in the set pixels
+// and the miss element fits in the unset pixels. Pixels past the border count as unset.
+func (pbm *PBM) HitOrMiss(hit, miss StructuringElement) {
+	hits, misses := hit.Offsets(), miss.Offsets()
+	newData := pbm.newData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			newData[y][x] = pbm.matches(x, y, hits, misses)
+		}
+	}
+	pbm.data = newData
+}
+
+// matches tells whether the hit offsets all land on set pixels and the miss offsets on unset pixels.
+func (pbm *PBM) matches(x, y int, hits, misses [][2]int) bool {
+	for _, o := range hits {
+		if !pbm.get(x+o[0], y+o[1], false) {
+			return false
+		}
+	}
+	for _, o := range misses {
+		if pbm.get(x+o[0], y+o[1], false) {
+			return false
+		}
+	}
+	return true
+}
+
+// Thin reduces the shapes of the PBM image to lines one pixel wide with the Zhang-Suen algorithm,
+// keeping their connectivity.
+func (pbm *PBM) Thin() {
+	for {
+		changed := pbm.thinPass(0)
+		if pbm.thinPass(1) {
+			changed = true
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+// thinPass runs one of the two Zhang-Suen sub-iterations and reports whether pixels were removed.
+func (pbm *PBM) thinPass(step int) bool {
+	var remove [][2]int
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if !pbm.data[y][x] {
+				continue
+			}
+			// Neighbors clockwise from the top: P2 to P9.
+			n := [8]bool{
+				pbm.get(x, y-1, false), pbm.get(x+1, y-1, false), pbm.get(x+1, y, false), pbm.get(x+1, y+1, false),
+				pbm.get(x, y+1, false), pbm.get(x-1, y+1, false), pbm.get(x-1, y, false), pbm.get(x-1, y-1, false),
+			}
+			count, transitions := 0, 0
+			for i := 0; i < 8; i++ {
+				if n[i] {
+					count++
+				}
+				if !n[i] && n[(i+1)%8] {
+					transitions++
+				}
+			}
+			if count < 2 || count > 6 || transitions != 1 {
+				continue
+			}
+			if step == 0 && (n[0] && n[2] && n[4] || n[2] && n[4] && n[6]) {
+				continue
+			}
+			if step == 1 && (n[0] && n[2] && n[6] || n[0] && n[4] && n[6]) {
+				continue
+			}
+			remove = append(remove, [2]int{x, y})
+		}
+	}
+	for _, p := range remove {
+		pbm.data[p[1]][p[0]] = false
+	}
+	return len(remove) > 0
+}
+
+// Skeletonize replaces the PBM image by its morphological skeleton (Lantuejoul's formula):
+// the union, over successive erosions by the structuring element, of what an opening removes.
+// The shapes can be rebuilt exactly from the skeleton, which may however be disconnected; use Thin
+// for a connected centerline.
+func (pbm *PBM) Skeletonize(se StructuringElement) {
+	skeleton := pbm.newData()
+	eroded := pbm.clone()
+	// Every erosion removes at least one layer of pixels, so the loop ends well before this bound.
+	for i := 0; i <= pbm.width+pbm.height; i++ {
+		opened := eroded.clone()
+		opened.Open(se)
+		empty := true
+		for y := 0; y < pbm.height; y++ {
+			for x := 0; x < pbm.width; x++ {
+				if eroded.data[y][x] {
+					empty = false
+					if !opened.data[y][x] {
+						skeleton[y][x] = true
+					}
+				}
+			}
+		}
+		if empty {
+			break
+		}
+		before := eroded.clone()
+		eroded.Erode(se)
+		if eroded.equal(before) {
+			// Shapes covering the whole image never erode away, keep them as they are.
+			for y := 0; y < pbm.height; y++ {
+				for x := 0; x < pbm.width; x++ {
+					skeleton[y][x] = skeleton[y][x] || eroded.data[y][x]
+				}
+			}
+			break
+		}
+	}
+	pbm.data = skeleton
+}
+
+// equal tells whether two images of the same size have the same pixels.
+func (pbm *PBM) equal(other *PBM) bool {
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] != other.data[y][x] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+package Netpbm
+
+import "testing"
+
+// newPBMFromRows builds an image from rows where '#' marks set pixels.
+func newPBMFromRows(rows ...string) *PBM {
+	pbm := NewPBM(len(rows[0]), len(rows))
+	for y, row := range rows {
+		for x, c := range row {
+			pbm.data[y][x] = c == '#'
+		}
+	}
+	return pbm
+}
+
+// checkRows compares an image with rows where '#' marks set pixels.
+func checkRows(t *testing.T, name string, pbm *PBM, rows ...string) {
+	t.Helper()
+	if pbm.width != len(rows[0]) || pbm.height != len(rows) {
+		t.Errorf("%s: wrong size %dx%d", name, pbm.width, pbm.height)
+		return
+	}
+	for y, row := range rows {
+		for x, c := range row {
+			if pbm.data[y][x] != (c == '#') {
+				t.Errorf("%s: wrong pixel at (%d, %d)", name, x, y)
+			}
+		}
+	}
+}
+
+// element builds a structuring element with one of the shape constructors.
+func element(t *testing.T, shape func(int) (StructuringElement, error), radius int) StructuringElement {
+	t.Helper()
+	se, err := shape(radius)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return se
+}
+
+func TestStructuringElements(t *testing.T) {
+	if n := len(element(t, SquareElement, 1).Offsets()); n != 9 {
+		t.Errorf("Square element has %d cells", n)
+	}
+	if n := len(element(t, CrossElement, 2).Offsets()); n != 9 {
+		t.Errorf("Cross element has %d cells", n)
+	}
+	if n := len(element(t, DiskElement, 2).Offsets()); n != 21 {
+		t.Errorf("Disk element has %d cells", n)
+	}
+	if _, err := NewStructuringElement([][]bool{{true, true}, {true}}, 0, 0); err == nil {
+		t.Error("Expected an error for rows of different lengths")
+	}
+	if _, err := NewStructuringElement([][]bool{{true, true}}, 0, 1); err == nil {
+		t.Error("Expected an error for an origin out of bounds")
+	}
+	for name, shape := range map[string]func(int) (StructuringElement, error){
+		"Square": SquareElement,
+		"Cross":  CrossElement,
+		"Disk":   DiskElement,
+	} {
+		if _, err := shape(-1); err == nil {
+			t.Errorf("%s element: expected an error for a negative radius", name)
+		}
+	}
+}
+
+func TestErodeDilate(t *testing.T) {
+	pbm := newPBMFromRows(
+		".......",
+		".###...",
+		".###..#",
+		".###...",
+		".......",
+	)
+	pbm.Erode(element(t, SquareElement, 1))
+	checkRows(t, "Erode", pbm,
+		".......",
+		".......",
+		"..#....",
+		".......",
+		".......",
+	)
+	pbm.Dilate(element(t, CrossElement, 1))
+	checkRows(t, "Dilate", pbm,
+		".......",
+		"..#....",
+		".###...",
+		"..#....",
+		".......",
+	)
+
+	// A structuring element with an offset origin shifts the result.
+	se, err := NewStructuringElement([][]bool{{true, false}}, 1, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	pbm = newPBMFromRows(".#..")
+	pbm.Dilate(se)
+	checkRows(t, "Shifted dilation", pbm, "#...")
+}
+
+func TestOpenClose(t *testing.T) {
+	pbm := newPBMFromRows(
+		"........",
+		"........",
+		"..####..",
+		"..#.##..",
+		"..####..",
+		"........",
+		"........",
+	)
+	pbm.Close(element(t, SquareElement, 1))
+	checkRows(t, "Close", pbm,
+		"........",
+		"........",
+		"..####..",
+		"..####..",
+		"..####..",
+		"........",
+		"........",
+	)
+	pbm.data[0][7] = true
+	pbm.Open(element(t, SquareElement, 1))
+	checkRows(t, "Open", pbm,
+		"........",
+		"........",
+		"..####..",
+		"..####..",
+		"..####..",
+		"........",
+		"........",
+	)
+	pbm.MorphologicalGradient(element(t, CrossElement, 1))
+	checkRows(t, "Gradient", pbm,
+		"........",
+		"..####..",
+		".######.",
+		".##..##.",
+		".######.",
+		"..####..",
+		"........",
+	)
+}
+
+func TestHitOrMiss(t *testing.T) {
+	hit := element(t, SquareElement, 0)
+	miss := element(t, SquareElement, 1)
+	miss.Data[1][1] = false
+	pbm := newPBMFromRows(
+		"#...##",
+		"......",
+		"..#...",
+	)
+	pbm.HitOrMiss(hit, miss)
+	checkRows(t, "HitOrMiss", pbm,
+		"#.....",
+		"......",
+		"..#...",
+	)
+}
+
+func TestThin(t *testing.T) {
+	pbm := NewPBM(20, 9)
+	for y := 2; y < 7; y++ {
+		for x := 2; x < 18; x++ {
+			pbm.data[y][x] = true
+		}
+	}
+	pbm.Thin()
+	for x := 5; x < 15; x++ {
+		count := 0
+		for y := 0; y < 9; y++ {
+			if pbm.data[y][x] {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("Column %d is %d pixels thick", x, count)
+		}
+	}
+}
+
+func TestSkeletonize(t *testing.T) {
+	pbm := newPBMFromRows(
+		".........",
+		".#######.",
+		".........",
+	)
+	pbm.Skeletonize(element(t, SquareElement, 1))
+	checkRows(t, "Skeleton of a line", pbm,
+		".........",
+		".#######.",
+		".........",
+	)
+
+	pbm = NewPBM(11, 11)
+	for y := 2; y < 9; y++ {
+		for x := 2; x < 9; x++ {
+			pbm.data[y][x] = true
+		}
+	}
+	pbm.Skeletonize(element(t, SquareElement, 1))
+	checkRows(t, "Skeleton of a square", pbm,
+		"...........",
+		"...........",
+		"...........",
+		"...........",
+		"...........",
+		".....#.....",
+		"...........",
+		"...........",
+		"...........",
+		"...........",
+		"...........",
+	)
+}
@@ -217,6 +217,94 @@ func (pbm *PBM) Flop() {
 	}
 }
 
+// Rotate90CW rotates the PBM image 90 degrees clockwise.
+func (pbm *PBM) Rotate90CW() {
+	newData := pbm.transposedData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			newData[x][pbm.height-y-1] = pbm.data[y][x]
+		}
+	}
+	pbm.width, pbm.height = pbm.height, pbm.width
+	pbm.data = newData
+}
+
+// Rotate90CCW rotates the PBM image 90 degrees counterclockwise.
+func (pbm *PBM) Rotate90CCW() {
+	newData := pbm.transposedData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			newData[pbm.width-x-1][y] = pbm.data[y][x]
+		}
+	}
+	pbm.width, pbm.height = pbm.height, pbm.width
+	pbm.data = newData
+}
+
+// Rotate180 rotates the PBM image 180 degrees.
+func (pbm *PBM) Rotate180() {
+	pbm.Flip()
+	pbm.Flop()
+}
+
+// Transpose mirrors the PBM image along its main diagonal (top-left to bottom-right).
+func (pbm *PBM) Transpose() {
+	newData := pbm.transposedData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			newData[x][y] = pbm.data[y][x]
+		}
+	}
+	pbm.width, pbm.height = pbm.height, pbm.width
+	pbm.data = newData
+}
+
+// Transverse mirrors the PBM image along its anti-diagonal (top-right to bottom-left).
+func (pbm *PBM) Transverse() {
+	newData := pbm.transposedData()
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			newData[pbm.width-x-1][pbm.height-y-1] = pbm.data[y][x]
+		}
+	}
+	pbm.width, pbm.height = pbm.height, pbm.width
+	pbm.data = newData
+}
+
+// transposedData allocates pixel rows for the image with width and height swapped.
+func (pbm *PBM) transposedData() [][]bool {
+	newData := make([][]bool, pbm.width)
+	for i := range newData {
+		newData[i] = make([]bool, pbm.height)
+	}
+	return newData
+}
+
+// ApplyOrientation applies the transform described by an EXIF orientation code (1 to 8),
+// turning an image stored with that orientation into an upright one.
+func (pbm *PBM) ApplyOrientation(orientation int) error {
+	switch orientation {
+	case 1:
+	case 2:
+		pbm.Flip()
+	case 3:
+		pbm.Rotate180()
+	case 4:
+		pbm.Flop()
+	case 5:
+		pbm.Transpose()
+	case 6:
+		pbm.Rotate90CW()
+	case 7:
+		pbm.Transverse()
+	case 8:
+		pbm.Rotate90CCW()
+	default:
+		return fmt.Errorf("invalid orientation: %d", orientation)
+	}
+	return nil
+}
+
 // SetMagicNumber sets the magic number of the PBM image.
 func (pbm *PBM) SetMagicNumber(magicNumber string) {
 	pbm.magicNumber = magicNumber
@@ -298,3 +298,77 @@ func TestSetMagicNumber(t *testing.T) {
 		t.Error("Wrong magic number")
 	}
 }
+
+func TestRotations(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(*PBM)
+		expected  [][]bool
+	}{
+		{"Rotate90CW", (*PBM).Rotate90CW, [][]bool{{false, true}, {false, true}, {true, false}}},
+		{"Rotate90CCW", (*PBM).Rotate90CCW, [][]bool{{false, true}, {true, false}, {true, false}}},
+		{"Rotate180", (*PBM).Rotate180, [][]bool{{true, false, false}, {false, true, true}}},
+		{"Transpose", (*PBM).Transpose, [][]bool{{true, false}, {true, false}, {false, true}}},
+		{"Transverse", (*PBM).Transverse, [][]bool{{true, false}, {false, true}, {false, true}}},
+	}
+	for _, test := range tests {
+		img := newOrientationPBM()
+		test.transform(img)
+		if img.width != len(test.expected[0]) || img.height != len(test.expected) {
+			t.Errorf("%s: wrong size %dx%d", test.name, img.width, img.height)
+			continue
+		}
+		for y := range test.expected {
+			for x := range test.expected[y] {
+				if img.data[y][x] != test.expected[y][x] {
+					t.Errorf("%s: pixel at (%d, %d) not transformed correctly", test.name, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// The 3x2 image newOrientationPBM, as it must look after each EXIF orientation is applied.
+	expected := map[int][][]bool{
+		1: {{true, true, false}, {false, false, true}},
+		2: {{false, true, true}, {true, false, false}},
+		3: {{true, false, false}, {false, true, true}},
+		4: {{false, false, true}, {true, true, false}},
+		5: {{true, false}, {true, false}, {false, true}},
+		6: {{false, true}, {false, true}, {true, false}},
+		7: {{true, false}, {false, true}, {false, true}},
+		8: {{false, true}, {true, false}, {true, false}},
+	}
+	for orientation := 1; orientation <= 8; orientation++ {
+		img := newOrientationPBM()
+		if err := img.ApplyOrientation(orientation); err != nil {
+			t.Error(err)
+		}
+		rows := expected[orientation]
+		if img.width != len(rows[0]) || img.height != len(rows) {
+			t.Errorf("Orientation %d: wrong size %dx%d", orientation, img.width, img.height)
+			continue
+		}
+		for y := range rows {
+			for x := range rows[y] {
+				if img.data[y][x] != rows[y][x] {
+					t.Errorf("Orientation %d: pixel at (%d, %d) not transformed correctly", orientation, x, y)
+				}
+			}
+		}
+	}
+	img := newOrientationPBM()
+	if err := img.ApplyOrientation(9); err == nil {
+		t.Error("Expected an error for an invalid orientation")
+	}
+}
+
+// newOrientationPBM returns a 3x2 image whose pattern changes under every orthogonal transform.
+func newOrientationPBM() *PBM {
+	pbm := NewPBM(3, 2)
+	pbm.data[0][0] = true
+	pbm.data[0][1] = true
+	pbm.data[1][2] = true
+	return pbm
+}
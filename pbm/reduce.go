@@ -0,0 +1,48 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// ReduceDithered shrinks the PBM image by a factor n in the manner of pbmreduce: every n x n block
+// becomes one gray level, the proportion of white (unset) pixels in the block, and the gray levels
+// are turned back into a bitmap with Floyd-Steinberg error diffusion.
+// The gray levels themselves are returned by Reduce in the pgm package.
+func (pbm *PBM) ReduceDithered(n int) (*PBM, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid reduction factor: %d", n)
+	}
+	return dither(raster.Reduce(pbm.width, pbm.height, n, pbm.At), 255), nil
+}
+
+// dither converts gray levels up to max to a PBM image with Floyd-Steinberg error diffusion.
+func dither(gray [][]uint8, max int) *PBM {
+	width, height := raster.ChannelSize(gray)
+	pbm := NewPBM(width, height)
+
+	current := make([]float64, width+2)
+	next := make([]float64, width+2)
+	threshold := float64(max) / 2
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := float64(gray[y][x]) + current[x+1]
+			target := float64(max)
+			if value < threshold {
+				pbm.data[y][x] = true
+				target = 0
+			}
+			e := value - target
+			current[x+2] += e * 7 / 16
+			next[x] += e * 3 / 16
+			next[x+1] += e * 5 / 16
+			next[x+2] += e * 1 / 16
+		}
+		current, next = next, current
+		for i := range next {
+			next[i] = 0
+		}
+	}
+	return pbm
+}
@@ -0,0 +1,28 @@
+package Netpbm
+
+import "testing"
+
+func TestReduceDithered(t *testing.T) {
+	pbm := NewPBM(64, 64)
+	// Every other pixel set: a 50% gray once reduced.
+	for y := 0; y < 64; y++ {
+		for x := (y % 2); x < 64; x += 2 {
+			pbm.data[y][x] = true
+		}
+	}
+	reduced, err := pbm.ReduceDithered(2)
+	if err != nil {
+		t.Error(err)
+	}
+	if reduced.width != 32 || reduced.height != 32 {
+		t.Errorf("Wrong size %dx%d", reduced.width, reduced.height)
+	}
+	count := countSet(reduced)
+	if count < 32*32*4/10 || count > 32*32*6/10 {
+		t.Errorf("Dithering does not preserve the gray level, %d pixels set", count)
+	}
+
+	if _, err := pbm.ReduceDithered(0); err == nil {
+		t.Error("Expected an error for an invalid factor")
+	}
+}
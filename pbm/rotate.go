@@ -0,0 +1,107 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Rotate rotates the PBM image by angle degrees counterclockwise around its center.
+// It uses the three-shear algorithm (Paeth), which only moves whole pixels and keeps edges crisp.
+// When expand is true the canvas grows to hold the whole rotated image, otherwise the size is kept.
+// Areas not covered by the source image take the fill value.
+func (pbm *PBM) Rotate(angle float64, expand bool, fill bool) {
+	radians := angle * math.Pi / 180
+	newWidth, newHeight := pbm.width, pbm.height
+	if expand {
+		newWidth, newHeight = raster.RotatedSize(pbm.width, pbm.height, math.Cos(radians), math.Sin(radians))
+	}
+
+	// Shears are only accurate up to 45 degrees, so take out right angles first.
+	angle = math.Mod(angle, 360)
+	for angle > 45 {
+		pbm.Rotate90CCW()
+		angle -= 90
+	}
+	for angle < -45 {
+		pbm.Rotate90CW()
+		angle += 90
+	}
+
+	data, width, height := pbm.data, pbm.width, pbm.height
+	if angle != 0 {
+		radians = angle * math.Pi / 180
+		alpha := math.Tan(radians / 2)
+		beta := -math.Sin(radians)
+		data, width = shearX(data, width, height, alpha, fill)
+		data, height = shearY(data, width, height, beta, fill)
+		data, width = shearX(data, width, height, alpha, fill)
+	}
+
+	pbm.data = centerCanvas(data, width, height, newWidth, newHeight, fill)
+	pbm.width, pbm.height = newWidth, newHeight
+}
+
+// shearX shifts every row by alpha times its distance to the vertical center.
+// It returns the sheared rows and their new width.
+func shearX(data [][]bool, width, height int, alpha float64, fill bool) ([][]bool, int) {
+	center := float64(height-1) / 2
+	margin := int(math.Abs(math.Round(alpha * center)))
+	newWidth := width + 2*margin
+
+	newData := make([][]bool, height)
+	for y := 0; y < height; y++ {
+		newData[y] = make([]bool, newWidth)
+		if fill {
+			for x := range newData[y] {
+				newData[y][x] = true
+			}
+		}
+		shift := int(math.Round(alpha * (float64(y) - center)))
+		copy(newData[y][margin+shift:], data[y])
+	}
+	return newData, newWidth
+}
+
+// shearY shifts every column by beta times its distance to the horizontal center.
+// It returns the sheared rows and their new height.
+func shearY(data [][]bool, width, height int, beta float64, fill bool) ([][]bool, int) {
+	center := float64(width-1) / 2
+	margin := int(math.Abs(math.Round(beta * center)))
+	newHeight := height + 2*margin
+
+	newData := make([][]bool, newHeight)
+	for y := range newData {
+		newData[y] = make([]bool, width)
+		if fill {
+			for x := range newData[y] {
+				newData[y][x] = true
+			}
+		}
+	}
+	for x := 0; x < width; x++ {
+		shift := int(math.Round(beta * (float64(x) - center)))
+		for y := 0; y < height; y++ {
+			newData[margin+shift+y][x] = data[y][x]
+		}
+	}
+	return newData, newHeight
+}
+
+// centerCanvas crops or pads rows to newWidth x newHeight, keeping the content centered.
+func centerCanvas(data [][]bool, width, height, newWidth, newHeight int, fill bool) [][]bool {
+	offsetX, offsetY := (width-newWidth)/2, (height-newHeight)/2
+	newData := make([][]bool, newHeight)
+	for y := 0; y < newHeight; y++ {
+		newData[y] = make([]bool, newWidth)
+		for x := 0; x < newWidth; x++ {
+			sx, sy := x+offsetX, y+offsetY
+			if sx < 0 || sy < 0 || sx >= width || sy >= height {
+				newData[y][x] = fill
+				continue
+			}
+			newData[y][x] = data[sy][sx]
+		}
+	}
+	return newData
+}
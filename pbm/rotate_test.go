@@ -0,0 +1,65 @@
+package Netpbm
+
+import "testing"
+
+func TestRotate(t *testing.T) {
+	pbm, err := ReadPBM("testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	expected, err := ReadPBM("testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	expected.Rotate90CCW()
+	pbm.Rotate(90, true, false)
+	if pbm.width != expected.width || pbm.height != expected.height {
+		t.Errorf("Wrong size %dx%d", pbm.width, pbm.height)
+	}
+	for y := 0; y < expected.height; y++ {
+		for x := 0; x < expected.width; x++ {
+			if pbm.data[y][x] != expected.data[y][x] {
+				t.Errorf("Pixel at (%d, %d) not rotated correctly", x, y)
+			}
+		}
+	}
+}
+
+func TestRotateShear(t *testing.T) {
+	pbm, err := ReadPBM("testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	count := countSet(pbm)
+
+	// Shears move whole pixels, so nothing is lost when the canvas expands.
+	pbm.Rotate(20, true, false)
+	if pbm.width != 20 || pbm.height != 20 {
+		t.Errorf("Wrong expanded size %dx%d", pbm.width, pbm.height)
+	}
+	if countSet(pbm) != count {
+		t.Errorf("Wrong number of set pixels, expected %d, got %d", count, countSet(pbm))
+	}
+
+	pbm = NewPBM(10, 10)
+	pbm.Rotate(-30, false, true)
+	if pbm.width != 10 || pbm.height != 10 {
+		t.Error("Size not kept")
+	}
+	if !pbm.data[0][0] || !pbm.data[9][9] || pbm.data[5][5] {
+		t.Error("Fill not applied to uncovered areas only")
+	}
+}
+
+// countSet returns the number of set pixels of the image.
+func countSet(pbm *PBM) int {
+	count := 0
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				count++
+			}
+		}
+	}
+	return count
+}
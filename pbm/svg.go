@@ -0,0 +1,139 @@
+package Netpbm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Segment is a piece of a path ending at End: a straight line, or a cubic Bezier curve
+// pulled by Control1 and Control2 when Cubic is true.
+type Segment struct {
+	Control1, Control2 PointF
+	End                PointF
+	Cubic              bool
+}
+
+// Path is a closed outline drawn from Start through its segments and back to Start.
+type Path struct {
+	Start    PointF
+	Segments []Segment
+}
+
+// Path returns the polygon drawn with straight lines.
+func (polygon Polygon) Path() Path {
+	if len(polygon) == 0 {
+		return Path{}
+	}
+	path := Path{Start: polygon[0]}
+	for _, p := range polygon[1:] {
+		path.Segments = append(path.Segments, Segment{End: p})
+	}
+	return path
+}
+
+// FitBezier smooths the polygon into a path of Bezier curves, in the spirit of potrace.
+// The path runs through the middle of every side; each corner turning by at most cornerAngle degrees
+// is rounded by a curve tangent to both sides, and sharper corners are kept as straight lines.
+// A cornerAngle of 0 keeps every corner, 180 rounds them all.
+func (polygon Polygon) FitBezier(cornerAngle float64) Path {
+	n := len(polygon)
+	if n < 3 {
+		return polygon.Path()
+	}
+	middle := func(i int) PointF {
+		a, b := polygon[(i+n)%n], polygon[(i+1)%n]
+		return PointF{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+	}
+
+	path := Path{Start: middle(n - 1)}
+	for i, v := range polygon {
+		before, after := polygon[(i+n-1)%n], polygon[(i+1)%n]
+		a, b := middle(i-1), middle(i)
+		turn := math.Abs(math.Atan2(
+			(v.X-before.X)*(after.Y-v.Y)-(v.Y-before.Y)*(after.X-v.X),
+			(v.X-before.X)*(after.X-v.X)+(v.Y-before.Y)*(after.Y-v.Y),
+		)) * 180 / math.Pi
+		if turn > cornerAngle {
+			path.Segments = append(path.Segments, Segment{End: v}, Segment{End: b})
+			continue
+		}
+		// The quadratic curve with control point v, raised to a cubic.
+		path.Segments = append(path.Segments, Segment{
+			Control1: PointF{a.X + 2*(v.X-a.X)/3, a.Y + 2*(v.Y-a.Y)/3},
+			Control2: PointF{b.X + 2*(v.X-b.X)/3, b.Y + 2*(v.Y-b.Y)/3},
+			End:      b,
+			Cubic:    true,
+		})
+	}
+	return path
+}
+
+// data returns the path as SVG path data.
+func (path Path) data() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "M%s %s", svgNumber(path.Start.X), svgNumber(path.Start.Y))
+	for _, s := range path.Segments {
+		if s.Cubic {
+			fmt.Fprintf(&builder, "C%s %s %s %s %s %s",
+				svgNumber(s.Control1.X), svgNumber(s.Control1.Y),
+				svgNumber(s.Control2.X), svgNumber(s.Control2.Y),
+				svgNumber(s.End.X), svgNumber(s.End.Y))
+		} else {
+			fmt.Fprintf(&builder, "L%s %s", svgNumber(s.End.X), svgNumber(s.End.Y))
+		}
+	}
+	builder.WriteString("Z")
+	return builder.String()
+}
+
+// svgNumber formats a coordinate with at most three decimals.
+func svgNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 3, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// WriteSVG writes the paths as a width x height SVG document, filled in black.
+// The paths form a single even-odd filled outline, so holes stay empty.
+func WriteSVG(w io.Writer, width, height int, paths []Path) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(writer, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+	if len(paths) > 0 {
+		fmt.Fprintf(writer, "<path fill=\"black\" fill-rule=\"evenodd\" d=\"")
+		for _, path := range paths {
+			writer.WriteString(path.data())
+		}
+		fmt.Fprintf(writer, "\"/>\n")
+	}
+	fmt.Fprintf(writer, "</svg>\n")
+	return writer.Flush()
+}
+
+// SaveSVG traces the set pixels of the PBM image and saves them as an SVG file.
+// Outlines are simplified with the given tolerance in pixels, then fitted with curves
+// rounding the corners that turn by at most cornerAngle degrees (see FitBezier).
+func (pbm *PBM) SaveSVG(filename string, tolerance, cornerAngle float64) error {
+	var paths []Path
+	for _, shape := range pbm.TraceContours() {
+		for _, polygon := range append([]Polygon{shape.Outer}, shape.Holes...) {
+			paths = append(paths, polygon.Simplify(tolerance).FitBezier(cornerAngle))
+		}
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return WriteSVG(file, pbm.width, pbm.height, paths)
+}
@@ -0,0 +1,78 @@
+package Netpbm
+
+import "testing"
+
+func TestArithmetic(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		operation Operation
+		overflow  Overflow
+		expected  []uint8
+	}{
+		{"Add", AddOperation, Saturate, []uint8{100, 150, 200}},
+		{"Scaled add", AddOperation, Scale, []uint8{57, 86, 200}},
+		{"Subtract", SubtractOperation, Saturate, []uint8{0, 50, 0}},
+		{"Scaled subtract", SubtractOperation, Scale, []uint8{0, 100, 33}},
+		{"Multiply", MultiplyOperation, Saturate, []uint8{0, 25, 150}},
+		{"Difference", DifferenceOperation, Saturate, []uint8{100, 50, 50}},
+		{"Min", MinOperation, Saturate, []uint8{0, 50, 150}},
+		{"Max", MaxOperation, Saturate, []uint8{100, 100, 200}},
+	} {
+		pgm := newRowPGM(200, 0, 100, 150)
+		if err := pgm.Arithmetic(c.operation, newRowPGM(200, 100, 50, 200), c.overflow); err != nil {
+			t.Error(err)
+		}
+		checkRow(t, c.name, pgm, c.expected...)
+	}
+}
+
+func TestArithmeticConstant(t *testing.T) {
+	pgm := newRowPGM(255, 0, 100, 200)
+	if err := pgm.ArithmeticConstant(AddOperation, 100, Saturate); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Add constant", pgm, 100, 200, 255)
+
+	pgm = newRowPGM(255, 0, 100, 200)
+	if err := pgm.ArithmeticConstant(MultiplyOperation, 0.5, Saturate); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Multiply constant", pgm, 0, 50, 100)
+
+	pgm = newRowPGM(255, 0, 100, 200)
+	if err := pgm.ArithmeticConstant(MultiplyOperation, 2, Scale); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Scaled multiply constant", pgm, 0, 128, 255)
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	pgm := newRowPGM(255, 0, 0)
+	if err := pgm.Arithmetic(AddOperation, newRowPGM(255, 0, 0, 0), Saturate); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	if err := pgm.Arithmetic(AddOperation, newRowPGM(100, 0, 0), Saturate); err == nil {
+		t.Error("Expected an error for a max mismatch")
+	}
+	if err := pgm.ArithmeticConstant(Operation(42), 1, Saturate); err == nil {
+		t.Error("Expected an error for an invalid operation")
+	}
+	if err := pgm.ArithmeticConstant(AddOperation, 1, Overflow(42)); err == nil {
+		t.Error("Expected an error for an invalid overflow mode")
+	}
+}
+
+func TestMean(t *testing.T) {
+	mean, err := Mean(newRowPGM(255, 0, 10, 255), newRowPGM(255, 30, 20, 255), newRowPGM(255, 0, 31, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRow(t, "Mean", mean, 10, 20, 170)
+
+	if _, err := Mean(); err == nil {
+		t.Error("Expected an error for an empty stack")
+	}
+	if _, err := Mean(newRowPGM(255, 0), newRowPGM(255, 0, 0)); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+}
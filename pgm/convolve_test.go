@@ -0,0 +1,133 @@
+package Netpbm
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestConvolveIdentityPGM(t *testing.T) {
+	pgm, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	identity, err := NewKernel(3, 3, []float64{0, 0, 0, 0, 1, 0, 0, 0, 0})
+	if err != nil {
+		t.Error(err)
+	}
+	for _, edge := range []EdgeMode{EdgeClamp, EdgeMirror, EdgeWrap, EdgeZero} {
+		if err := pgm.Convolve(identity, edge); err != nil {
+			t.Error(err)
+		}
+	}
+	for i := 0; i < imagePGMWidth*imagePGMHeight; i++ {
+		x := i % imagePGMWidth
+		y := i / imagePGMWidth
+		if pgm.data[y][x] != testData[i] {
+			t.Errorf("Pixel at (%d, %d) changed by the identity kernel", x, y)
+		}
+	}
+
+	if _, err := NewKernel(2, 3, make([]float64, 6)); err == nil {
+		t.Error("Expected an error for an even kernel size")
+	}
+	if err := pgm.Convolve(Kernel{Width: 3, Height: 3}, EdgeClamp); err == nil {
+		t.Error("Expected an error for a kernel without weights")
+	}
+}
+
+func TestConvolveBlurPGM(t *testing.T) {
+	box, err := BoxBlurKernel(2)
+	if err != nil {
+		t.Error(err)
+	}
+	gaussian, err := GaussianKernel(1.2)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, kernel := range []Kernel{box, gaussian} {
+		for _, edge := range []EdgeMode{EdgeClamp, EdgeMirror, EdgeWrap, EdgeZero} {
+			pgm := NewPGM(9, 7, 255)
+			for y := 0; y < pgm.height; y++ {
+				for x := 0; x < pgm.width; x++ {
+					pgm.data[y][x] = 120
+				}
+			}
+			if err := pgm.Convolve(kernel, edge); err != nil {
+				t.Error(err)
+			}
+			if pgm.data[3][4] != 120 {
+				t.Errorf("Edge mode %d: center of a flat image changed to %d", edge, pgm.data[3][4])
+			}
+			if edge == EdgeZero && pgm.data[0][0] >= 120 {
+				t.Error("Zero edges should darken the corners")
+			}
+			if edge != EdgeZero && pgm.data[0][0] != 120 {
+				t.Errorf("Edge mode %d: corner of a flat image changed to %d", edge, pgm.data[0][0])
+			}
+		}
+	}
+}
+
+func TestBlurKernelErrorsPGM(t *testing.T) {
+	if _, err := BoxBlurKernel(-1); err == nil {
+		t.Error("Expected an error for a negative radius")
+	}
+	for _, sigma := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := GaussianKernel(sigma); err == nil {
+			t.Errorf("Expected an error for a standard deviation of %v", sigma)
+		}
+	}
+	if kernel, err := BoxBlurKernel(0); err != nil || kernel.Width != 1 || kernel.At(0, 0) != 1 {
+		t.Errorf("Box blur of radius 0 should be the identity, got %+v, %v", kernel, err)
+	}
+}
+
+func TestConvolveSobelPGM(t *testing.T) {
+	pgm := NewPGM(6, 3, 255)
+	for y := 0; y < 3; y++ {
+		for x := 3; x < 6; x++ {
+			pgm.data[y][x] = 100
+		}
+	}
+	kernel := SobelXKernel()
+	kernel.Bias = 10
+	if err := pgm.Convolve(kernel, EdgeClamp); err != nil {
+		t.Error(err)
+	}
+	expected := []uint8{10, 10, 255, 255, 10, 10}
+	for x, value := range expected {
+		if pgm.data[1][x] != value {
+			t.Errorf("Pixel at (%d, 1) expected %d, got %d", x, value, pgm.data[1][x])
+		}
+	}
+}
+
+func TestLoadKernelPGM(t *testing.T) {
+	content := "# emboss\n-1 -1 0\n-1 0 1  # middle row\n0 1 1\n\ndivisor 2\nbias 64\n"
+	err := os.WriteFile("kernel.txt", []byte(content), 0644)
+	if err != nil {
+		t.Error(err)
+	}
+	kernel, err := LoadKernel("kernel.txt")
+	if err != nil {
+		t.Error(err)
+	}
+	if kernel.Width != 3 || kernel.Height != 3 || kernel.At(2, 1) != 1 || kernel.Divisor != 2 || kernel.Bias != 64 {
+		t.Errorf("Kernel not read correctly: %+v", kernel)
+	}
+
+	err = os.WriteFile("kernel.txt", []byte("1 2 3\n4 5\n"), 0644)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := LoadKernel("kernel.txt"); err == nil {
+		t.Error("Expected an error for rows of different lengths")
+	}
+
+	// remove the test file
+	err = os.Remove("kernel.txt")
+	if err != nil {
+		t.Error(err)
+	}
+}
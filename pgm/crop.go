@@ -0,0 +1,82 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Rect represents a rectangular area of an image.
+type Rect = raster.Rect
+
+// Crop keeps only the area of the PGM image inside rect.
+func (pgm *PGM) Crop(rect Rect) error {
+	newData, err := raster.Crop(pgm.data, pgm.width, pgm.height, rect)
+	if err != nil {
+		return err
+	}
+	pgm.data = newData
+	pgm.width, pgm.height = rect.Width, rect.Height
+	return nil
+}
+
+// AutoCrop trims the borders of the PGM image that have the color of its top-left corner,
+// give or take tolerance. It returns the area that was kept.
+func (pgm *PGM) AutoCrop(tolerance int) (Rect, error) {
+	if pgm.width == 0 || pgm.height == 0 {
+		return Rect{}, nil
+	}
+	return pgm.AutoCropColor(pgm.data[0][0], tolerance)
+}
+
+// AutoCropColor trims the borders of the PGM image that have the given value, give or take tolerance.
+// It returns the area that was kept. An image made only of the border value is left untouched.
+func (pgm *PGM) AutoCropColor(value uint8, tolerance int) (Rect, error) {
+	isBorder := func(x, y int) bool {
+		d := int(pgm.data[y][x]) - int(value)
+		return d <= tolerance && -d <= tolerance
+	}
+	rect := Rect{Width: pgm.width, Height: pgm.height}
+	if trimmed, ok := raster.TrimBounds(pgm.width, pgm.height, isBorder); ok {
+		if err := pgm.Crop(trimmed); err != nil {
+			return Rect{}, err
+		}
+		rect = trimmed
+	}
+	return rect, nil
+}
+
+// Pad grows the canvas of the PGM image by the given number of pixels on each side,
+// filling the new area with the fill value.
+func (pgm *PGM) Pad(top, right, bottom, left int, fill uint8) error {
+	return pgm.pad(top, right, bottom, left, func(x, y int) uint8 {
+		return fill
+	})
+}
+
+// PadReplicate grows the canvas of the PGM image by the given number of pixels on each side,
+// extending the edge pixels into the new area.
+func (pgm *PGM) PadReplicate(top, right, bottom, left int) error {
+	if pgm.width == 0 || pgm.height == 0 {
+		return errors.New("cannot replicate the edges of an empty image")
+	}
+	return pgm.pad(top, right, bottom, left, func(x, y int) uint8 {
+		return pgm.data[raster.ClampInt(y, 0, pgm.height-1)][raster.ClampInt(x, 0, pgm.width-1)]
+	})
+}
+
+// Margin adds a border of the same size on every side of the PGM image.
+func (pgm *PGM) Margin(size int, fill uint8) error {
+	return pgm.Pad(size, size, size, size, fill)
+}
+
+// pad grows the canvas, asking outside for the value of every new pixel in source coordinates.
+func (pgm *PGM) pad(top, right, bottom, left int, outside func(x, y int) uint8) error {
+	newData, err := raster.Pad(pgm.data, pgm.width, pgm.height, top, right, bottom, left, outside)
+	if err != nil {
+		return err
+	}
+	pgm.data = newData
+	pgm.width, pgm.height = pgm.width+left+right, pgm.height+top+bottom
+	return nil
+}
@@ -0,0 +1,103 @@
+package Netpbm
+
+import "testing"
+
+func TestCropPGM(t *testing.T) {
+	pgm, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	if err := pgm.Crop(Rect{X: 3, Y: 2, Width: 5, Height: 4}); err != nil {
+		t.Error(err)
+	}
+	if pgm.width != 5 || pgm.height != 4 {
+		t.Errorf("Wrong size %dx%d", pgm.width, pgm.height)
+	}
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 5; x++ {
+			if pgm.data[y][x] != testData[(y+2)*imagePGMWidth+x+3] {
+				t.Errorf("Pixel at (%d, %d) not cropped correctly", x, y)
+			}
+		}
+	}
+	if err := pgm.Crop(Rect{X: 3, Y: 0, Width: 5, Height: 4}); err == nil {
+		t.Error("Expected an error for an area out of the image")
+	}
+}
+
+func TestAutoCropPGM(t *testing.T) {
+	pgm := NewPGM(10, 8, 255)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = 200
+		}
+	}
+	pgm.data[0][9] = 198
+	pgm.data[2][3] = 10
+	pgm.data[5][6] = 20
+
+	rect, err := pgm.AutoCrop(2)
+	if err != nil {
+		t.Error(err)
+	}
+	if rect != (Rect{X: 3, Y: 2, Width: 4, Height: 4}) {
+		t.Errorf("Wrong trimmed area %v", rect)
+	}
+	if pgm.width != 4 || pgm.height != 4 || pgm.data[0][0] != 10 || pgm.data[3][3] != 20 {
+		t.Error("Image not trimmed correctly")
+	}
+
+	pgm = NewPGM(4, 4, 255)
+	if rect, err := pgm.AutoCropColor(0, 0); err != nil || rect.Width != 4 || rect.Height != 4 || pgm.width != 4 {
+		t.Error("Uniform image should be left untouched")
+	}
+}
+
+func TestPadPGM(t *testing.T) {
+	pgm := NewPGM(2, 2, 255)
+	pgm.data = [][]uint8{{1, 2}, {3, 4}}
+	if err := pgm.Pad(1, 2, 0, 1, 9); err != nil {
+		t.Error(err)
+	}
+	expected := [][]uint8{
+		{9, 9, 9, 9, 9},
+		{9, 1, 2, 9, 9},
+		{9, 3, 4, 9, 9},
+	}
+	if pgm.width != 5 || pgm.height != 3 {
+		t.Errorf("Wrong size %dx%d", pgm.width, pgm.height)
+	}
+	for y := range expected {
+		for x := range expected[y] {
+			if pgm.data[y][x] != expected[y][x] {
+				t.Errorf("Pixel at (%d, %d) not padded correctly", x, y)
+			}
+		}
+	}
+
+	pgm = NewPGM(2, 2, 255)
+	pgm.data = [][]uint8{{1, 2}, {3, 4}}
+	if err := pgm.PadReplicate(1, 1, 1, 1); err != nil {
+		t.Error(err)
+	}
+	expected = [][]uint8{
+		{1, 1, 2, 2},
+		{1, 1, 2, 2},
+		{3, 3, 4, 4},
+		{3, 3, 4, 4},
+	}
+	for y := range expected {
+		for x := range expected[y] {
+			if pgm.data[y][x] != expected[y][x] {
+				t.Errorf("Pixel at (%d, %d) not replicated correctly", x, y)
+			}
+		}
+	}
+
+	if err := pgm.Margin(3, 0); err != nil || pgm.width != 10 || pgm.height != 10 {
+		t.Error("Margin not added correctly")
+	}
+	if err := pgm.Pad(-1, 0, 0, 0, 0); err == nil {
+		t.Error("Expected an error for a negative padding")
+	}
+}
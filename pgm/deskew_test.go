@@ -0,0 +1,40 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDeskewPGM(t *testing.T) {
+	pgm := NewPGM(200, 120, 255)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = 255
+		}
+	}
+	for line := 20; line < 100; line += 12 {
+		for x := 20; x < 180; x++ {
+			if x%7 != 6 {
+				pgm.data[line][x] = 0
+				pgm.data[line+1][x] = 0
+			}
+		}
+	}
+	pgm.Rotate(2.5, Bilinear, false, 255)
+
+	angle, confidence := pgm.DetectSkew(8)
+	if math.Abs(angle-2.5) > 0.3 {
+		t.Errorf("Wrong skew angle, expected about 2.5, got %v", angle)
+	}
+	if confidence < 0.3 {
+		t.Errorf("Confidence too low: %v", confidence)
+	}
+
+	angle, _ = pgm.Deskew(8)
+	if math.Abs(angle-2.5) > 0.3 {
+		t.Errorf("Wrong deskew angle, got %v", angle)
+	}
+	if residual, _ := pgm.DetectSkew(8); math.Abs(residual) > 0.3 {
+		t.Errorf("Page still skewed by %v", residual)
+	}
+}
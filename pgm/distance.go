@@ -0,0 +1,45 @@
+package Netpbm
+
+import (
+	"fmt"
+	"math"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+)
+
+// DistanceMap returns the distance transform of the PBM image as a PGM image with a max value of 255,
+// where a distance of maxDistance or more is white. maxDistance must be positive and finite.
+func DistanceMap(pbm *bitmap.PBM, metric bitmap.DistanceMetric, maxDistance float64) (*PGM, error) {
+	if maxDistance <= 0 || math.IsNaN(maxDistance) || math.IsInf(maxDistance, 0) {
+		return nil, fmt.Errorf("invalid maximum distance: %v", maxDistance)
+	}
+	distances := pbm.DistanceTransform(metric)
+	width, height := pbm.Size()
+	pgm := NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pgm.data[y][x] = uint8(math.Round(math.Min(distances[y][x]/maxDistance, 1) * 255))
+		}
+	}
+	return pgm, nil
+}
+
+// SignedDistanceMap returns the signed distance field of the PBM image as a PGM image with a max value
+// of 255, in the usual layout of distance field fonts: the outline is mid-gray, the inside of the shapes
+// is brighter, and distances of spread or more on either side are white or black.
+// spread must be positive and finite.
+func SignedDistanceMap(pbm *bitmap.PBM, metric bitmap.DistanceMetric, spread float64) (*PGM, error) {
+	if spread <= 0 || math.IsNaN(spread) || math.IsInf(spread, 0) {
+		return nil, fmt.Errorf("invalid spread: %v", spread)
+	}
+	field := pbm.SignedDistanceField(metric)
+	width, height := pbm.Size()
+	pgm := NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := math.Max(-1, math.Min(1, field[y][x]/spread))
+			pgm.data[y][x] = uint8(math.Round((1 - v) * 127.5))
+		}
+	}
+	return pgm, nil
+}
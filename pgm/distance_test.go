@@ -0,0 +1,42 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+)
+
+func TestDistanceMaps(t *testing.T) {
+	bits := bitmap.NewPBM(8, 6)
+	for y := 1; y < 5; y++ {
+		for x := 2; x < 6; x++ {
+			bits.Set(x, y, true)
+		}
+	}
+
+	pgm, err := SignedDistanceMap(bits, bitmap.Euclidean, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.max != 255 || pgm.data[2][0] != 32 || pgm.data[2][3] != 223 || pgm.data[2][1] != 96 {
+		t.Errorf("Wrong signed distance map %v", pgm.data)
+	}
+
+	pgm, err = DistanceMap(bits, bitmap.Manhattan, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.data[2][2] != 0 || pgm.data[2][0] != 128 || pgm.data[0][0] != 191 {
+		t.Errorf("Wrong distance map %v", pgm.data)
+	}
+
+	for _, invalid := range []float64{0, -2, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := DistanceMap(bits, bitmap.Euclidean, invalid); err == nil {
+			t.Errorf("Expected an error for a maximum distance of %v", invalid)
+		}
+		if _, err := SignedDistanceMap(bits, bitmap.Euclidean, invalid); err == nil {
+			t.Errorf("Expected an error for a spread of %v", invalid)
+		}
+	}
+}
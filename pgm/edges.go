@@ -0,0 +1,74 @@
+package Netpbm
+
+import (
+	"github.com/dolobe/Netpbm/internal/raster"
+	bitmap "github.com/dolobe/Netpbm/pbm"
+)
+
+// PrewittXKernel returns the 3x3 Prewitt kernel responding to horizontal changes.
+func PrewittXKernel() Kernel {
+	return raster.PrewittXKernel()
+}
+
+// PrewittYKernel returns the 3x3 Prewitt kernel responding to vertical changes.
+func PrewittYKernel() Kernel {
+	return raster.PrewittYKernel()
+}
+
+// ScharrXKernel returns the 3x3 Scharr kernel responding to horizontal changes.
+func ScharrXKernel() Kernel {
+	return raster.ScharrXKernel()
+}
+
+// ScharrYKernel returns the 3x3 Scharr kernel responding to vertical changes.
+func ScharrYKernel() Kernel {
+	return raster.ScharrYKernel()
+}
+
+// Sobel computes the gradient of the PGM image with the Sobel operator.
+// See gradientImages for the meaning of the returned images.
+func (pgm *PGM) Sobel() (magnitude, direction *PGM) {
+	return pgm.gradientImages(raster.Sobel)
+}
+
+// Prewitt computes the gradient of the PGM image with the Prewitt operator.
+// See gradientImages for the meaning of the returned images.
+func (pgm *PGM) Prewitt() (magnitude, direction *PGM) {
+	return pgm.gradientImages(raster.Prewitt)
+}
+
+// Scharr computes the gradient of the PGM image with the Scharr operator.
+// See gradientImages for the meaning of the returned images.
+func (pgm *PGM) Scharr() (magnitude, direction *PGM) {
+	return pgm.gradientImages(raster.Scharr)
+}
+
+// gradientImages applies a gradient operator to the image and returns two PGM images
+// with the same max value. In the magnitude image a sharp step from black to white reaches max.
+// In the direction image the angle of the gradient, from -180 to 180 degrees, is spread over [0, max].
+func (pgm *PGM) gradientImages(op raster.Operator) (magnitude, direction *PGM) {
+	magnitude = NewPGM(pgm.width, pgm.height, pgm.max)
+	direction = NewPGM(pgm.width, pgm.height, pgm.max)
+	magnitude.data, direction.data = raster.Gradient(pgm.data, op, pgm.max)
+	return magnitude, direction
+}
+
+// Canny detects edges in the PGM image with the Canny algorithm and returns them as set pixels of a PBM image.
+// The image is smoothed with a Gaussian of standard deviation sigma (no smoothing if sigma is 0),
+// differentiated with the Sobel operator, thinned by non-maximum suppression, then edges are kept by
+// hysteresis: pixels whose gradient magnitude reaches high start an edge, which extends through pixels
+// reaching low. Both thresholds are fractions of the max value.
+// It returns an error if sigma is negative or not finite, or unless 0 <= low <= high.
+func (pgm *PGM) Canny(sigma, low, high float64) (*bitmap.PBM, error) {
+	edges, err := raster.Canny(pgm.data, pgm.max, sigma, low, high)
+	if err != nil {
+		return nil, err
+	}
+	pbm := bitmap.NewPBM(pgm.width, pgm.height)
+	for y, row := range edges {
+		for x, edge := range row {
+			pbm.Set(x, y, edge)
+		}
+	}
+	return pbm, nil
+}
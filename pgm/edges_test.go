@@ -0,0 +1,100 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+// newStepPGM returns an image black on the left half and white on the right half.
+func newStepPGM() *PGM {
+	pgm := NewPGM(10, 6, 200)
+	for y := 0; y < pgm.height; y++ {
+		for x := 5; x < pgm.width; x++ {
+			pgm.data[y][x] = 200
+		}
+	}
+	return pgm
+}
+
+func TestGradientOperatorsPGM(t *testing.T) {
+	operators := map[string]func(*PGM) (*PGM, *PGM){
+		"Sobel":   (*PGM).Sobel,
+		"Prewitt": (*PGM).Prewitt,
+		"Scharr":  (*PGM).Scharr,
+	}
+	for name, operator := range operators {
+		magnitude, direction := operator(newStepPGM())
+		if magnitude.max != 200 || direction.max != 200 {
+			t.Errorf("%s: max value not kept", name)
+		}
+		for y := 0; y < 6; y++ {
+			if magnitude.data[y][4] != 200 || magnitude.data[y][5] != 200 {
+				t.Errorf("%s: wrong magnitude on the edge at row %d: %d %d", name, y, magnitude.data[y][4], magnitude.data[y][5])
+			}
+			if magnitude.data[y][1] != 0 || magnitude.data[y][8] != 0 {
+				t.Errorf("%s: flat areas should have no gradient", name)
+			}
+			if direction.data[y][4] != 100 {
+				t.Errorf("%s: wrong direction for a left to right edge, got %d", name, direction.data[y][4])
+			}
+		}
+	}
+}
+
+func TestCannyPGM(t *testing.T) {
+	pgm := NewPGM(20, 20, 255)
+	for y := 5; y < 15; y++ {
+		for x := 5; x < 15; x++ {
+			pgm.data[y][x] = 255
+		}
+	}
+	edges, err := pgm.Canny(1, 0.1, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if width, height := edges.Size(); width != 20 || height != 20 {
+		t.Error("Wrong size")
+	}
+	if edges.At(10, 10) || edges.At(0, 0) {
+		t.Error("Flat areas should have no edges")
+	}
+	for i := 6; i < 14; i++ {
+		if !edges.At(4, i) && !edges.At(5, i) {
+			t.Errorf("Left side of the square not detected at row %d", i)
+		}
+		if !edges.At(i, 4) && !edges.At(i, 5) {
+			t.Errorf("Top side of the square not detected at column %d", i)
+		}
+		// Non-maximum suppression leaves one pixel wide lines.
+		if edges.At(4, i) && edges.At(5, i) {
+			t.Errorf("Edge not thinned at row %d", i)
+		}
+	}
+
+	edges, err = pgm.Canny(1, 0.9, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edges.At(5, 10) || edges.At(4, 10) {
+		t.Error("Edges weaker than the thresholds should be dropped")
+	}
+	if _, err := pgm.Canny(0, 0.1, 0.3); err != nil {
+		t.Errorf("Canny without smoothing failed: %v", err)
+	}
+}
+
+func TestCannyErrorsPGM(t *testing.T) {
+	pgm := newStepPGM()
+	for _, params := range [][3]float64{
+		{-1, 0.1, 0.3},
+		{math.NaN(), 0.1, 0.3},
+		{math.Inf(1), 0.1, 0.3},
+		{1, -0.1, 0.3},
+		{1, 0.5, 0.3},
+		{1, math.NaN(), 0.3},
+	} {
+		if _, err := pgm.Canny(params[0], params[1], params[2]); err == nil {
+			t.Errorf("Canny%v should fail", params)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Histogram returns the number of pixels of the PGM image for every value from 0 to max.
+func (pgm *PGM) Histogram() []int {
+	return raster.Histogram(pgm.data, pgm.max)
+}
+
+// Equalize spreads the values of the PGM image so that its histogram becomes as flat as possible.
+func (pgm *PGM) Equalize() {
+	raster.Equalize([][][]uint8{pgm.data}, pgm.max)
+}
+
+// CLAHE applies contrast-limited adaptive histogram equalization to the PGM image.
+// The image is split into a tilesX x tilesY grid equalized tile by tile. No value may be counted more
+// than clipLimit (at least 1) times the average count per value in a tile, which limits how much noise
+// is amplified; usual values are between 2 and 4. The tables of neighboring tiles are blended to avoid seams.
+func (pgm *PGM) CLAHE(tilesX, tilesY int, clipLimit float64) error {
+	return raster.CLAHE([][][]uint8{pgm.data}, pgm.max, tilesX, tilesY, clipLimit)
+}
+
+// MatchHistogram remaps the values of the PGM image so that its histogram resembles the one
+// of the reference image, which may have another size and max value.
+func (pgm *PGM) MatchHistogram(reference *PGM) error {
+	if reference.width*reference.height == 0 {
+		return errors.New("empty reference image")
+	}
+	raster.MatchHistogram(pgm.data, pgm.max, reference.Histogram(), reference.max)
+	return nil
+}
+
+// AutoContrast stretches the values of the PGM image to the full range, in the manner of pnmnorm:
+// the darkest lowPercent of the pixels become black, the brightest highPercent become white,
+// and values in between are spread linearly.
+func (pgm *PGM) AutoContrast(lowPercent, highPercent float64) error {
+	return raster.AutoContrast([][][]uint8{pgm.data}, pgm.max, lowPercent, highPercent)
+}
@@ -0,0 +1,116 @@
+package Netpbm
+
+import "testing"
+
+// newRowPGM creates a one-row PGM image holding the given values.
+func newRowPGM(max int, values ...uint8) *PGM {
+	pgm := NewPGM(len(values), 1, max)
+	copy(pgm.data[0], values)
+	return pgm
+}
+
+// checkRow compares the only row of a PGM image with the expected values.
+func checkRow(t *testing.T, name string, pgm *PGM, expected ...uint8) {
+	t.Helper()
+	for x, v := range expected {
+		if pgm.data[0][x] != v {
+			t.Errorf("%s: got %v, expected %v", name, pgm.data[0], expected)
+			return
+		}
+	}
+}
+
+func TestHistogramPGM(t *testing.T) {
+	pgm := newRowPGM(100, 0, 3, 3, 100)
+	counts := pgm.Histogram()
+	if len(counts) != 101 || counts[0] != 1 || counts[3] != 2 || counts[100] != 1 {
+		t.Errorf("Wrong histogram %v", counts)
+	}
+}
+
+func TestEqualizePGM(t *testing.T) {
+	pgm := newRowPGM(255, 10, 20, 20, 30, 40, 40)
+	pgm.Equalize()
+	checkRow(t, "Equalize", pgm, 0, 102, 102, 153, 255, 255)
+
+	pgm = newRowPGM(255, 7, 7)
+	pgm.Equalize()
+	checkRow(t, "Equalize a flat image", pgm, 7, 7)
+}
+
+func TestAutoContrastPGM(t *testing.T) {
+	pgm := newRowPGM(200, 50, 75, 100, 150)
+	if err := pgm.AutoContrast(0, 0); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "AutoContrast", pgm, 0, 50, 100, 200)
+
+	// Clipping a quarter of the pixels on each side.
+	pgm = newRowPGM(255, 0, 100, 150, 255)
+	if err := pgm.AutoContrast(25, 25); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "AutoContrast with clipping", pgm, 0, 0, 255, 255)
+
+	if err := pgm.AutoContrast(60, 40); err == nil {
+		t.Error("Expected an error for percentages reaching 100")
+	}
+}
+
+func TestMatchHistogramPGM(t *testing.T) {
+	pgm := newRowPGM(255, 0, 1, 2, 3)
+	if err := pgm.MatchHistogram(newRowPGM(255, 100, 200)); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "MatchHistogram", pgm, 100, 100, 200, 200)
+
+	// The reference values are scaled to the max value of the image.
+	pgm = newRowPGM(255, 5, 9)
+	if err := pgm.MatchHistogram(newRowPGM(10, 0, 10)); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "MatchHistogram with another max", pgm, 0, 255)
+
+	if err := pgm.MatchHistogram(NewPGM(0, 0, 255)); err == nil {
+		t.Error("Expected an error for an empty reference")
+	}
+}
+
+func TestCLAHEPGM(t *testing.T) {
+	original := NewPGM(32, 32, 255)
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			original.data[y][x] = uint8(100 + 10*((x+y)%2))
+		}
+	}
+
+	pgm := NewPGM(32, 32, 255)
+	for y := range pgm.data {
+		copy(pgm.data[y], original.data[y])
+	}
+	if err := pgm.CLAHE(2, 2, 4); err != nil {
+		t.Error(err)
+	}
+	if d := int(pgm.data[10][11]) - int(pgm.data[10][10]); d <= 10 {
+		t.Errorf("Expected a stronger contrast, got a difference of %d", d)
+	}
+
+	// The lowest clip limit keeps values almost unchanged.
+	if err := original.CLAHE(2, 2, 1); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			if d := int(original.data[y][x]) - 100 - 10*((x+y)%2); d < -3 || d > 3 {
+				t.Errorf("Pixel at (%d, %d) changed by %d", x, y, d)
+			}
+		}
+	}
+
+	if err := pgm.CLAHE(0, 2, 2); err == nil {
+		t.Error("Expected an error for an empty tile grid")
+	}
+	if err := pgm.CLAHE(2, 2, 0.5); err == nil {
+		t.Error("Expected an error for a clip limit below 1")
+	}
+}
@@ -0,0 +1,22 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// Interpolation selects how pixel values are computed between pixel centers.
+type Interpolation = raster.Interpolation
+
+const (
+	// NearestNeighbor uses the value of the closest pixel.
+	NearestNeighbor = raster.NearestNeighbor
+	// Bilinear blends the 2x2 closest pixels.
+	Bilinear = raster.Bilinear
+	// Bicubic blends the 4x4 closest pixels with a cubic convolution kernel.
+	Bicubic = raster.Bicubic
+)
+
+// warp resamples the image onto a new width x height grid.
+// For every destination pixel, inverse returns the matching source coordinates,
+// or false when the pixel has no source and must take the fill value.
+func (pgm *PGM) warp(width, height int, inverse func(x, y float64) (float64, float64, bool), interp Interpolation, fill uint8) [][]uint8 {
+	return raster.Warp([][][]uint8{pgm.data}, width, height, inverse, interp, pgm.max, []uint8{fill})[0]
+}
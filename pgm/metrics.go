@@ -0,0 +1,64 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// checkComparable returns an error unless the two PGM images have the same size and max value.
+func checkComparable(a, b *PGM) error {
+	if a.width != b.width || a.height != b.height {
+		return fmt.Errorf("image sizes differ: %dx%d and %dx%d", a.width, a.height, b.width, b.height)
+	}
+	if a.max != b.max {
+		return fmt.Errorf("max values differ: %d and %d", a.max, b.max)
+	}
+	if a.width*a.height == 0 {
+		return errors.New("empty images")
+	}
+	return nil
+}
+
+// MSE returns the mean squared error between two PGM images of the same size and max value.
+func MSE(a, b *PGM) (float64, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, err
+	}
+	return raster.MSE(a.data, b.data), nil
+}
+
+// PSNR returns the peak signal-to-noise ratio in decibels between two PGM images of the same size
+// and max value, as pnmpsnr does: 10 log10(max² / MSE). Identical images give +Inf.
+func PSNR(a, b *PGM) (float64, error) {
+	mse, err := MSE(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return raster.PSNR(mse, a.max), nil
+}
+
+// SSIM returns the mean structural similarity between two PGM images of the same size and max value,
+// and the map of the similarity of every pixel as a PGM image with a max value of 255.
+// Statistics are gathered in a Gaussian window with a standard deviation of 1.5 pixels.
+// The similarity is 1 for identical images; the map shows negative similarities as black.
+func SSIM(a, b *PGM) (float64, *PGM, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, nil, err
+	}
+	similarity, similarityMap := raster.SSIM([][][]uint8{a.data}, [][][]uint8{b.data}, a.max)
+	ssimMap := NewPGM(a.width, a.height, 255)
+	ssimMap.data = similarityMap
+	return similarity, ssimMap, nil
+}
+
+// MSSSIM returns the multi-scale structural similarity between two PGM images of the same size and
+// max value. The images are compared at five scales, halving their size each time, so they must be
+// at least 16 pixels wide and high.
+func MSSSIM(a, b *PGM) (float64, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, err
+	}
+	return raster.MSSSIM([][][]uint8{a.data}, [][][]uint8{b.data}, a.max)
+}
@@ -0,0 +1,98 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// newTexturePGM returns a 32x32 PGM image with a smooth gradient and a checkered texture.
+func newTexturePGM() *PGM {
+	pgm := NewPGM(32, 32, 255)
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			pgm.data[y][x] = uint8(4*x + 2*y + 40*((x/4+y/4)%2))
+		}
+	}
+	return pgm
+}
+
+func TestMSEAndPSNR(t *testing.T) {
+	a, b := newRowPGM(255, 0, 100, 200), newRowPGM(255, 10, 90, 210)
+	mse, err := MSE(a, b)
+	if err != nil || mse != 100 {
+		t.Errorf("MSE: got %v (%v), expected 100", mse, err)
+	}
+	psnr, err := PSNR(a, b)
+	if expected := 10 * math.Log10(255*255/100.0); err != nil || math.Abs(psnr-expected) > 1e-9 {
+		t.Errorf("PSNR: got %v (%v), expected %v", psnr, err, expected)
+	}
+	if psnr, _ := PSNR(a, a); !math.IsInf(psnr, 1) {
+		t.Errorf("PSNR of identical images: got %v, expected +Inf", psnr)
+	}
+
+	if _, err := MSE(a, newRowPGM(255, 0, 0)); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	if _, err := PSNR(a, newRowPGM(100, 0, 0, 0)); err == nil {
+		t.Error("Expected an error for a max mismatch")
+	}
+}
+
+func TestSSIM(t *testing.T) {
+	a := newTexturePGM()
+	similarity, ssimMap, err := SSIM(a, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(similarity-1) > 1e-9 {
+		t.Errorf("SSIM of identical images: got %v, expected 1", similarity)
+	}
+	if ssimMap.width != 32 || ssimMap.height != 32 || ssimMap.max != 255 || ssimMap.data[10][20] != 255 {
+		t.Errorf("Unexpected SSIM map of identical images")
+	}
+
+	// Noise lowers the similarity less than inverting the image does.
+	noisy, inverted := a.clone(), a.clone()
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			noisy.data[y][x] = uint8(raster.ClampInt(int(a.data[y][x])+(x*7+y*13)%21-10, 0, 255))
+			inverted.data[y][x] = 255 - a.data[y][x]
+		}
+	}
+	noisySimilarity, _, _ := SSIM(a, noisy)
+	invertedSimilarity, invertedMap, _ := SSIM(a, inverted)
+	if noisySimilarity >= 1 || noisySimilarity < 0.5 || invertedSimilarity >= noisySimilarity {
+		t.Errorf("Unexpected SSIM: %v with noise, %v inverted", noisySimilarity, invertedSimilarity)
+	}
+	if reversed, _, _ := SSIM(noisy, a); math.Abs(reversed-noisySimilarity) > 1e-9 {
+		t.Errorf("SSIM is not symmetric: %v and %v", noisySimilarity, reversed)
+	}
+	if invertedMap.data[16][16] != 0 {
+		t.Errorf("Expected negative similarities to be black in the map, got %d", invertedMap.data[16][16])
+	}
+
+	if _, _, err := SSIM(a, NewPGM(32, 31, 255)); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+}
+
+func TestMSSSIM(t *testing.T) {
+	a := newTexturePGM()
+	if similarity, err := MSSSIM(a, a); err != nil || math.Abs(similarity-1) > 1e-9 {
+		t.Errorf("MS-SSIM of identical images: got %v (%v), expected 1", similarity, err)
+	}
+	noisy := a.clone()
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			noisy.data[y][x] = uint8(raster.ClampInt(int(a.data[y][x])+(x*7+y*13)%21-10, 0, 255))
+		}
+	}
+	if similarity, err := MSSSIM(a, noisy); err != nil || similarity >= 1 || similarity < 0.5 {
+		t.Errorf("MS-SSIM with noise: got %v (%v)", similarity, err)
+	}
+	if _, err := MSSSIM(NewPGM(15, 32, 255), NewPGM(15, 32, 255)); err == nil {
+		t.Error("Expected an error for images too small")
+	}
+}
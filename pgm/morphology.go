@@ -0,0 +1,188 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+	bitmap "github.com/dolobe/Netpbm/pbm"
+)
+
+// StructuringElement is the flat shape probing the image in morphological operations.
+// Data holds Height rows of Width cells; set cells belong to the shape.
+// The origin is the cell placed over the pixel being computed.
+type StructuringElement = bitmap.StructuringElement
+
+// NewStructuringElement creates a structuring element from rows of cells and the position of its origin.
+func NewStructuringElement(data [][]bool, originX, originY int) (StructuringElement, error) {
+	return bitmap.NewStructuringElement(data, originX, originY)
+}
+
+// SquareElement returns a (2*radius+1) square structuring element.
+// The radius must not be negative.
+func SquareElement(radius int) (StructuringElement, error) {
+	return bitmap.SquareElement(radius)
+}
+
+// CrossElement returns a cross-shaped structuring element with arms of the given radius.
+// The radius must not be negative.
+func CrossElement(radius int) (StructuringElement, error) {
+	return bitmap.CrossElement(radius)
+}
+
+// DiskElement returns a disk-shaped structuring element of the given radius.
+// The radius must not be negative.
+func DiskElement(radius int) (StructuringElement, error) {
+	return bitmap.DiskElement(radius)
+}
+
+// clone returns a copy of the PGM image.
+func (pgm *PGM) clone() *PGM {
+	copied := NewPGM(pgm.width, pgm.height, pgm.max)
+	copied.magicNumber = pgm.magicNumber
+	for y := range pgm.data {
+		copy(copied.data[y], pgm.data[y])
+	}
+	return copied
+}
+
+// morph replaces every pixel by the darkest (or brightest) value under the offsets.
+// Offsets falling past the border are ignored.
+func (pgm *PGM) morph(offsets [][2]int, brightest bool) {
+	result := make([][]uint8, pgm.height)
+	raster.ParallelRows(pgm.height, func(start, end int) {
+		for y := start; y < end; y++ {
+			result[y] = make([]uint8, pgm.width)
+			for x := 0; x < pgm.width; x++ {
+				value, found := pgm.data[y][x], false
+				for _, o := range offsets {
+					sx, sy := x+o[0], y+o[1]
+					if sx < 0 || sy < 0 || sx >= pgm.width || sy >= pgm.height {
+						continue
+					}
+					v := pgm.data[sy][sx]
+					if !found || (brightest && v > value) || (!brightest && v < value) {
+						value, found = v, true
+					}
+				}
+				result[y][x] = value
+			}
+		}
+	})
+	pgm.data = result
+}
+
+// Erode replaces every pixel of the PGM image by the darkest value under the structuring element,
+// shrinking bright regions.
+func (pgm *PGM) Erode(se StructuringElement) {
+	pgm.morph(se.Offsets(), false)
+}
+
+// Dilate replaces every pixel of the PGM image by the brightest value under the reflected
+// structuring element, growing bright regions.
+func (pgm *PGM) Dilate(se StructuringElement) {
+	offsets := se.Offsets()
+	for i := range offsets {
+		offsets[i] = [2]int{-offsets[i][0], -offsets[i][1]}
+	}
+	pgm.morph(offsets, true)
+}
+
+// Open erodes then dilates the PGM image, removing bright details smaller than the structuring element.
+func (pgm *PGM) Open(se StructuringElement) {
+	pgm.Erode(se)
+	pgm.Dilate(se)
+}
+
+// Close dilates then erodes the PGM image, removing dark details smaller than the structuring element.
+func (pgm *PGM) Close(se StructuringElement) {
+	pgm.Dilate(se)
+	pgm.Erode(se)
+}
+
+// TopHat keeps the bright details of the PGM image smaller than the structuring element:
+// the image minus its opening. With an element larger than the objects of interest,
+// this subtracts a slowly varying background. Differences are clamped at 0, which only matters
+// for elements not covering their origin.
+func (pgm *PGM) TopHat(se StructuringElement) {
+	opened := pgm.clone()
+	opened.Open(se)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = uint8(max(int(pgm.data[y][x])-int(opened.data[y][x]), 0))
+		}
+	}
+}
+
+// BlackHat keeps the dark details of the PGM image smaller than the structuring element:
+// the closing minus the image. Dark details come out bright. Differences are clamped at 0.
+func (pgm *PGM) BlackHat(se StructuringElement) {
+	closed := pgm.clone()
+	closed.Close(se)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = uint8(max(int(closed.data[y][x])-int(pgm.data[y][x]), 0))
+		}
+	}
+}
+
+// Reconstruct performs the morphological reconstruction by dilation of the PGM image, used as the
+// marker, under mask: the marker is dilated with 8-connectivity over and over, never rising above
+// the mask, until it stops changing. Marker values above the mask are first lowered to it.
+//
+// Reconstructing the mask from itself lowered by a constant h, then subtracting the result from
+// the mask, extracts the bright features higher than h whatever the background level.
+func (pgm *PGM) Reconstruct(mask *PGM) error {
+	if mask.width != pgm.width || mask.height != pgm.height {
+		return errors.New("mask size does not match the image")
+	}
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			if pgm.data[y][x] > mask.data[y][x] {
+				pgm.data[y][x] = mask.data[y][x]
+			}
+		}
+	}
+
+	// Alternate raster and anti-raster scans, each propagating values from the neighbors
+	// already visited, until a full round changes nothing.
+	forward := [][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}}
+	backward := [][2]int{{1, 1}, {0, 1}, {-1, 1}, {1, 0}}
+	for changed := true; changed; {
+		changed = false
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				if pgm.propagate(mask, x, y, forward) {
+					changed = true
+				}
+			}
+		}
+		for y := pgm.height - 1; y >= 0; y-- {
+			for x := pgm.width - 1; x >= 0; x-- {
+				if pgm.propagate(mask, x, y, backward) {
+					changed = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// propagate raises the pixel at (x, y) to the brightest of its neighbors at the offsets, limited by the mask,
+// and reports whether it changed.
+func (pgm *PGM) propagate(mask *PGM, x, y int, neighbors [][2]int) bool {
+	value := pgm.data[y][x]
+	for _, n := range neighbors {
+		nx, ny := x+n[0], y+n[1]
+		if nx >= 0 && ny >= 0 && nx < pgm.width && ny < pgm.height && pgm.data[ny][nx] > value {
+			value = pgm.data[ny][nx]
+		}
+	}
+	if value > mask.data[y][x] {
+		value = mask.data[y][x]
+	}
+	if value == pgm.data[y][x] {
+		return false
+	}
+	pgm.data[y][x] = value
+	return true
+}
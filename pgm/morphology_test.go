@@ -0,0 +1,149 @@
+package Netpbm
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// element builds a structuring element with one of the shape constructors.
+func element(t *testing.T, shape func(int) (StructuringElement, error), radius int) StructuringElement {
+	t.Helper()
+	se, err := shape(radius)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return se
+}
+
+func TestErodeDilatePGM(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	original := NewPGM(13, 9, 255)
+	for y := 0; y < original.height; y++ {
+		for x := 0; x < original.width; x++ {
+			original.data[y][x] = uint8(random.Intn(256))
+		}
+	}
+
+	// A square element gives the same result as the min and max filters.
+	for _, dilate := range []bool{false, true} {
+		pgm, expected := original.clone(), original.clone()
+		if dilate {
+			pgm.Dilate(element(t, SquareElement, 2))
+			expected.MaxFilter(2, SquareWindow)
+		} else {
+			pgm.Erode(element(t, SquareElement, 2))
+			expected.MinFilter(2, SquareWindow)
+		}
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				if pgm.data[y][x] != expected.data[y][x] {
+					t.Errorf("Dilate %v: got %d at (%d, %d), expected %d", dilate, pgm.data[y][x], x, y, expected.data[y][x])
+				}
+			}
+		}
+	}
+
+	// An element with an offset origin shifts the image.
+	se, err := NewStructuringElement([][]bool{{true, false}}, 1, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	pgm := NewPGM(4, 1, 255)
+	pgm.data[0] = []uint8{10, 20, 30, 40}
+	pgm.Dilate(se)
+	for x, expected := range []uint8{20, 30, 40, 40} {
+		if pgm.data[0][x] != expected {
+			t.Errorf("Shifted dilation: got %d at %d, expected %d", pgm.data[0][x], x, expected)
+		}
+	}
+}
+
+func TestTopHatBlackHat(t *testing.T) {
+	pgm := NewPGM(9, 9, 255)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = 100
+		}
+	}
+	pgm.data[2][2] = 220
+	pgm.data[6][6] = 30
+
+	topHat := pgm.clone()
+	topHat.TopHat(element(t, SquareElement, 1))
+	blackHat := pgm.clone()
+	blackHat.BlackHat(element(t, SquareElement, 1))
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			expectedTop, expectedBlack := uint8(0), uint8(0)
+			if x == 2 && y == 2 {
+				expectedTop = 120
+			}
+			if x == 6 && y == 6 {
+				expectedBlack = 70
+			}
+			if topHat.data[y][x] != expectedTop {
+				t.Errorf("TopHat: got %d at (%d, %d), expected %d", topHat.data[y][x], x, y, expectedTop)
+			}
+			if blackHat.data[y][x] != expectedBlack {
+				t.Errorf("BlackHat: got %d at (%d, %d), expected %d", blackHat.data[y][x], x, y, expectedBlack)
+			}
+		}
+	}
+}
+
+func TestTopHatBlackHatClamp(t *testing.T) {
+	// An element not covering its origin can lift the opening above the image
+	// and lower the closing below it; the differences must stop at 0.
+	se, err := NewStructuringElement([][]bool{{true, false}}, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pgm := NewPGM(4, 1, 255)
+	pgm.data[0] = []uint8{100, 0, 100, 0}
+	topHat, blackHat := pgm.clone(), pgm.clone()
+	topHat.TopHat(se)
+	blackHat.BlackHat(se)
+	for x := 0; x < 4; x++ {
+		if topHat.data[0][x] != 0 || blackHat.data[0][x] != 0 {
+			t.Errorf("Got %d and %d at %d, expected 0", topHat.data[0][x], blackHat.data[0][x], x)
+		}
+	}
+	if _, err := DiskElement(-1); err == nil {
+		t.Error("Expected an error for a negative radius")
+	}
+}
+
+func TestReconstruct(t *testing.T) {
+	mask := NewPGM(10, 5, 255)
+	for y := 0; y < mask.height; y++ {
+		for x := 0; x < mask.width; x++ {
+			mask.data[y][x] = 20
+		}
+	}
+	// Two bright blobs, the first one shaped like an L.
+	for _, p := range [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 3}, {3, 3}, {7, 2}, {8, 2}} {
+		mask.data[p[1]][p[0]] = 200
+	}
+	mask.data[3][3] = 180
+
+	marker := NewPGM(10, 5, 255)
+	marker.data[1][1] = 255
+	if err := marker.Reconstruct(mask); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < mask.height; y++ {
+		for x := 0; x < mask.width; x++ {
+			expected := mask.data[y][x]
+			if x >= 7 {
+				expected = 20
+			}
+			if marker.data[y][x] != expected {
+				t.Errorf("Reconstruct: got %d at (%d, %d), expected %d", marker.data[y][x], x, y, expected)
+			}
+		}
+	}
+
+	if err := marker.Reconstruct(NewPGM(3, 3, 255)); err == nil {
+		t.Error("Expected an error for a mask of a different size")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
 )
 
 // PGM represents a PGM image.
@@ -195,6 +197,11 @@ func (pgm *PGM) Flop() {
 	}
 }
 
+// Max returns the max value of the image.
+func (pgm *PGM) Max() int {
+	return pgm.max
+}
+
 // SetMagicNumber sets the magic number of the PGM image.
 func (pgm *PGM) SetMagicNumber(magicNumber string) {
 	pgm.magicNumber = magicNumber
@@ -231,6 +238,88 @@ func (pgm *PGM) Rotate90CW() {
 	pgm.data = newData
 }
 
+// Rotate90CCW rotates the PGM image 90 degrees counterclockwise.
+func (pgm *PGM) Rotate90CCW() {
+	newData := pgm.transposedData()
+
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			newData[pgm.width-1-x][y] = pgm.data[y][x]
+		}
+	}
+
+	pgm.width, pgm.height = pgm.height, pgm.width
+	pgm.data = newData
+}
+
+// Rotate180 rotates the PGM image 180 degrees.
+func (pgm *PGM) Rotate180() {
+	pgm.Flip()
+	pgm.Flop()
+}
+
+// Transpose mirrors the PGM image along its main diagonal (top-left to bottom-right).
+func (pgm *PGM) Transpose() {
+	newData := pgm.transposedData()
+
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			newData[x][y] = pgm.data[y][x]
+		}
+	}
+
+	pgm.width, pgm.height = pgm.height, pgm.width
+	pgm.data = newData
+}
+
+// Transverse mirrors the PGM image along its anti-diagonal (top-right to bottom-left).
+func (pgm *PGM) Transverse() {
+	newData := pgm.transposedData()
+
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			newData[pgm.width-1-x][pgm.height-1-y] = pgm.data[y][x]
+		}
+	}
+
+	pgm.width, pgm.height = pgm.height, pgm.width
+	pgm.data = newData
+}
+
+// transposedData allocates pixel rows for the image with width and height swapped.
+func (pgm *PGM) transposedData() [][]uint8 {
+	newData := make([][]uint8, pgm.width)
+	for i := range newData {
+		newData[i] = make([]uint8, pgm.height)
+	}
+	return newData
+}
+
+// ApplyOrientation applies the transform described by an EXIF orientation code (1 to 8),
+// turning an image stored with that orientation into an upright one.
+func (pgm *PGM) ApplyOrientation(orientation int) error {
+	switch orientation {
+	case 1:
+	case 2:
+		pgm.Flip()
+	case 3:
+		pgm.Rotate180()
+	case 4:
+		pgm.Flop()
+	case 5:
+		pgm.Transpose()
+	case 6:
+		pgm.Rotate90CW()
+	case 7:
+		pgm.Transverse()
+	case 8:
+		pgm.Rotate90CCW()
+	default:
+		return fmt.Errorf("invalid orientation: %d", orientation)
+	}
+	return nil
+}
+
 // ToPBM converts the PGM image to a PBM image (black and white).
 func (pgm *PGM) ToPBM() *PBM {
 	pbm := &PBM{}
@@ -249,6 +338,23 @@ func (pgm *PGM) ToPBM() *PBM {
 	return pbm
 }
 
+// FromPBM converts a PBM image to a PGM image with a max value of 255.
+// Set (black) pixels take the foreground value and unset pixels take the background value.
+func FromPBM(pbm *bitmap.PBM, foreground, background uint8) *PGM {
+	width, height := pbm.Size()
+	pgm := NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if pbm.At(x, y) {
+				pgm.data[y][x] = foreground
+			} else {
+				pgm.data[y][x] = background
+			}
+		}
+	}
+	return pgm
+}
+
 // NewPGM creates a new instance of the PGM structure with the specified dimensions.
 func NewPGM(width, height, max int) *PGM {
 	data := make([][]uint8, height)
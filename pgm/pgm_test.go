@@ -3,6 +3,8 @@ package Netpbm
 import (
 	"os"
 	"testing"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
 )
 
 const imagePGMWidth = 15
@@ -365,3 +367,104 @@ func TestToPBM(t *testing.T) {
 		}
 	}
 }
+
+func TestFromPBM(t *testing.T) {
+	bits := bitmap.NewPBM(3, 2)
+	bits.Set(0, 0, true)
+	bits.Set(2, 1, true)
+	pgm := FromPBM(bits, 10, 200)
+	if pgm.magicNumber != "P2" {
+		t.Error("Wrong magic number")
+	}
+	if width, height := pgm.Size(); width != 3 || height != 2 {
+		t.Errorf("Wrong size %dx%d", width, height)
+	}
+	if pgm.Max() != 255 {
+		t.Errorf("Wrong max value %d", pgm.Max())
+	}
+	expected := [][]uint8{{10, 200, 200}, {200, 200, 10}}
+	for y, row := range expected {
+		for x, value := range row {
+			if pgm.At(x, y) != value {
+				t.Errorf("Pixel at (%d, %d) not converted correctly, expected %d, got %d", x, y, value, pgm.At(x, y))
+			}
+		}
+	}
+}
+
+func TestRotationsPGM(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(*PGM)
+		expected  [][]uint8
+	}{
+		{"Rotate90CW", (*PGM).Rotate90CW, [][]uint8{{4, 1}, {5, 2}, {6, 3}}},
+		{"Rotate90CCW", (*PGM).Rotate90CCW, [][]uint8{{3, 6}, {2, 5}, {1, 4}}},
+		{"Rotate180", (*PGM).Rotate180, [][]uint8{{6, 5, 4}, {3, 2, 1}}},
+		{"Transpose", (*PGM).Transpose, [][]uint8{{1, 4}, {2, 5}, {3, 6}}},
+		{"Transverse", (*PGM).Transverse, [][]uint8{{6, 3}, {5, 2}, {4, 1}}},
+	}
+	for _, test := range tests {
+		img := newOrientationPGM()
+		test.transform(img)
+		if img.width != len(test.expected[0]) || img.height != len(test.expected) {
+			t.Errorf("%s: wrong size %dx%d", test.name, img.width, img.height)
+			continue
+		}
+		for y := range test.expected {
+			for x := range test.expected[y] {
+				if img.data[y][x] != test.expected[y][x] {
+					t.Errorf("%s: pixel at (%d, %d) not transformed correctly", test.name, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestApplyOrientationPGM(t *testing.T) {
+	// The values of newOrientationPGM, laid out as they must be after each EXIF orientation is applied.
+	expected := map[int][][]uint8{
+		1: {{1, 2, 3}, {4, 5, 6}},
+		2: {{3, 2, 1}, {6, 5, 4}},
+		3: {{6, 5, 4}, {3, 2, 1}},
+		4: {{4, 5, 6}, {1, 2, 3}},
+		5: {{1, 4}, {2, 5}, {3, 6}},
+		6: {{4, 1}, {5, 2}, {6, 3}},
+		7: {{6, 3}, {5, 2}, {4, 1}},
+		8: {{3, 6}, {2, 5}, {1, 4}},
+	}
+	for orientation := 1; orientation <= 8; orientation++ {
+		img := newOrientationPGM()
+		if err := img.ApplyOrientation(orientation); err != nil {
+			t.Error(err)
+		}
+		rows := expected[orientation]
+		if img.width != len(rows[0]) || img.height != len(rows) {
+			t.Errorf("Orientation %d: wrong size %dx%d", orientation, img.width, img.height)
+			continue
+		}
+		for y := range rows {
+			for x := range rows[y] {
+				if img.data[y][x] != rows[y][x] {
+					t.Errorf("Orientation %d: pixel at (%d, %d) not transformed correctly, expected %d, got %d",
+						orientation, x, y, rows[y][x], img.data[y][x])
+				}
+			}
+		}
+	}
+	img := newOrientationPGM()
+	if err := img.ApplyOrientation(9); err == nil {
+		t.Error("Expected an error for an invalid orientation")
+	}
+}
+
+// newOrientationPGM returns a 3x2 image with a distinct value in every pixel.
+func newOrientationPGM() *PGM {
+	pgm := NewPGM(3, 2, 6)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			pgm.data[y][x] = uint8(y*3 + x + 1)
+		}
+	}
+	return pgm
+}
@@ -0,0 +1,35 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// WindowShape selects the neighborhood used by rank filters.
+type WindowShape = raster.WindowShape
+
+const (
+	// SquareWindow covers a (2*radius+1) square.
+	SquareWindow = raster.SquareWindow
+	// CircularWindow covers the pixels within radius of the center.
+	CircularWindow = raster.CircularWindow
+)
+
+// Median replaces every pixel of the PGM image by the median of its neighborhood,
+// which removes salt-and-pepper noise while keeping edges.
+func (pgm *PGM) Median(radius int, shape WindowShape) error {
+	return pgm.RankFilter(radius, 50, shape)
+}
+
+// MinFilter replaces every pixel of the PGM image by the darkest value of its neighborhood.
+func (pgm *PGM) MinFilter(radius int, shape WindowShape) error {
+	return pgm.RankFilter(radius, 0, shape)
+}
+
+// MaxFilter replaces every pixel of the PGM image by the brightest value of its neighborhood.
+func (pgm *PGM) MaxFilter(radius int, shape WindowShape) error {
+	return pgm.RankFilter(radius, 100, shape)
+}
+
+// RankFilter replaces every pixel of the PGM image by the given percentile (0 to 100) of its neighborhood.
+// Neighborhoods are clipped at the border of the image.
+func (pgm *PGM) RankFilter(radius int, percentile float64, shape WindowShape) error {
+	return raster.RankFilter([][][]uint8{pgm.data}, radius, percentile, shape)
+}
@@ -0,0 +1,106 @@
+package Netpbm
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// bruteRank computes a rank filter by sorting every neighborhood.
+func bruteRank(pgm *PGM, radius int, percentile float64, shape WindowShape) [][]uint8 {
+	result := make([][]uint8, pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		result[y] = make([]uint8, pgm.width)
+		for x := 0; x < pgm.width; x++ {
+			var values []int
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					if shape == CircularWindow && dx*dx+dy*dy > radius*radius {
+						continue
+					}
+					if x+dx >= 0 && y+dy >= 0 && x+dx < pgm.width && y+dy < pgm.height {
+						values = append(values, int(pgm.data[y+dy][x+dx]))
+					}
+				}
+			}
+			sort.Ints(values)
+			result[y][x] = uint8(values[int(math.Round(percentile/100*float64(len(values)-1)))])
+		}
+	}
+	return result
+}
+
+func TestRankFilterPGM(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	original := NewPGM(23, 17, 255)
+	for y := 0; y < original.height; y++ {
+		for x := 0; x < original.width; x++ {
+			original.data[y][x] = uint8(random.Intn(256))
+		}
+	}
+	for _, shape := range []WindowShape{SquareWindow, CircularWindow} {
+		for _, radius := range []int{0, 1, 3} {
+			for _, percentile := range []float64{0, 25, 50, 90, 100} {
+				pgm := NewPGM(23, 17, 255)
+				for y := range pgm.data {
+					copy(pgm.data[y], original.data[y])
+				}
+				if err := pgm.RankFilter(radius, percentile, shape); err != nil {
+					t.Error(err)
+				}
+				expected := bruteRank(original, radius, percentile, shape)
+				for y := 0; y < pgm.height; y++ {
+					for x := 0; x < pgm.width; x++ {
+						if pgm.data[y][x] != expected[y][x] {
+							t.Fatalf("Shape %d, radius %d, percentile %v: pixel at (%d, %d) expected %d, got %d",
+								shape, radius, percentile, x, y, expected[y][x], pgm.data[y][x])
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if err := original.RankFilter(-1, 50, SquareWindow); err == nil {
+		t.Error("Expected an error for a negative radius")
+	}
+	if err := original.RankFilter(1, 101, SquareWindow); err == nil {
+		t.Error("Expected an error for a percentile above 100")
+	}
+}
+
+func TestMedianPGM(t *testing.T) {
+	pgm := NewPGM(9, 9, 255)
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			pgm.data[y][x] = 80
+		}
+	}
+	pgm.data[2][3] = 255
+	pgm.data[6][6] = 0
+	if err := pgm.Median(1, SquareWindow); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			if pgm.data[y][x] != 80 {
+				t.Errorf("Noise left at (%d, %d): %d", x, y, pgm.data[y][x])
+			}
+		}
+	}
+
+	pgm.data[4][4] = 200
+	if err := pgm.MaxFilter(2, CircularWindow); err != nil {
+		t.Error(err)
+	}
+	if pgm.data[2][4] != 200 || pgm.data[2][2] != 80 {
+		t.Error("Circular window not applied correctly")
+	}
+	if err := pgm.MinFilter(2, CircularWindow); err != nil {
+		t.Error(err)
+	}
+	if pgm.data[4][4] != 200 || pgm.data[3][4] != 80 {
+		t.Error("Opening with min and max filters should restore the spot")
+	}
+}
@@ -0,0 +1,22 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+	bitmap "github.com/dolobe/Netpbm/pbm"
+)
+
+// Reduce shrinks a PBM image by a factor n into a PGM image with a max value of 255,
+// in the manner of pbmreduce: every n x n block becomes one gray pixel whose value is
+// the proportion of white (unset) pixels in the block. Partial blocks on the right and
+// bottom edges are averaged over the pixels they contain.
+func Reduce(pbm *bitmap.PBM, n int) (*PGM, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid reduction factor: %d", n)
+	}
+	width, height := pbm.Size()
+	pgm := NewPGM((width+n-1)/n, (height+n-1)/n, 255)
+	pgm.data = raster.Reduce(width, height, n, pbm.At)
+	return pgm, nil
+}
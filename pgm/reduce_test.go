@@ -0,0 +1,38 @@
+package Netpbm
+
+import (
+	"testing"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+)
+
+func TestReduce(t *testing.T) {
+	bits := bitmap.NewPBM(6, 5)
+	// Four black pixels in the top-left 4x4 block, one in the bottom-left partial block
+	// and both pixels of the bottom-right partial block.
+	for _, p := range [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {0, 4}, {4, 4}, {5, 4}} {
+		bits.Set(p[0], p[1], true)
+	}
+	pgm, err := Reduce(bits, 4)
+	if err != nil {
+		t.Error(err)
+	}
+	if width, height := pgm.Size(); width != 2 || height != 2 {
+		t.Errorf("Wrong size %dx%d", width, height)
+	}
+	if pgm.Max() != 255 {
+		t.Errorf("Wrong max value %d", pgm.Max())
+	}
+	expected := [][]uint8{{191, 255}, {191, 0}}
+	for y, row := range expected {
+		for x, value := range row {
+			if pgm.At(x, y) != value {
+				t.Errorf("Wrong gray level at (%d, %d), expected %d, got %d", x, y, value, pgm.At(x, y))
+			}
+		}
+	}
+
+	if _, err := Reduce(bits, 0); err == nil {
+		t.Error("Expected an error for an invalid factor")
+	}
+}
@@ -0,0 +1,61 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Filter selects the resampling kernel used to resize an image.
+type Filter = raster.Filter
+
+const (
+	// NearestFilter copies the closest source pixel.
+	NearestFilter = raster.NearestFilter
+	// BilinearFilter uses a triangle kernel.
+	BilinearFilter = raster.BilinearFilter
+	// BicubicFilter uses the Catmull-Rom cubic kernel.
+	BicubicFilter = raster.BicubicFilter
+	// LanczosFilter uses a three-lobed Lanczos windowed sinc kernel.
+	LanczosFilter = raster.LanczosFilter
+	// AreaFilter averages the source pixels covered by each destination pixel, best for downscaling.
+	AreaFilter = raster.AreaFilter
+)
+
+// Resize scales the PGM image to width x height with the given resampling filter.
+// Rows are resampled horizontally, then columns vertically, in parallel.
+func (pgm *PGM) Resize(width, height int, filter Filter) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid size")
+	}
+	if pgm.width == 0 || pgm.height == 0 {
+		return errors.New("cannot resize an empty image")
+	}
+
+	pgm.data = raster.Resize([][][]uint8{pgm.data}, width, height, filter, pgm.max)[0]
+	pgm.width, pgm.height = width, height
+	return nil
+}
+
+// ResizeFit scales the PGM image to fit inside maxWidth x maxHeight, preserving its aspect ratio.
+func (pgm *PGM) ResizeFit(maxWidth, maxHeight int, filter Filter) error {
+	if maxWidth <= 0 || maxHeight <= 0 {
+		return errors.New("invalid size")
+	}
+	width, height := raster.FitSize(pgm.width, pgm.height, maxWidth, maxHeight, false)
+	return pgm.Resize(width, height, filter)
+}
+
+// ResizeFill scales the PGM image to cover width x height, preserving its aspect ratio,
+// then crops the overflow evenly on both sides.
+func (pgm *PGM) ResizeFill(width, height int, filter Filter) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid size")
+	}
+	scaledWidth, scaledHeight := raster.FitSize(pgm.width, pgm.height, width, height, true)
+	if err := pgm.Resize(scaledWidth, scaledHeight, filter); err != nil {
+		return err
+	}
+
+	return pgm.Crop(Rect{X: (scaledWidth - width) / 2, Y: (scaledHeight - height) / 2, Width: width, Height: height})
+}
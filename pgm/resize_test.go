@@ -0,0 +1,90 @@
+package Netpbm
+
+import "testing"
+
+func TestResizePGM(t *testing.T) {
+	for _, filter := range []Filter{NearestFilter, BilinearFilter, BicubicFilter, LanczosFilter, AreaFilter} {
+		pgm := NewPGM(40, 30, 255)
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				pgm.data[y][x] = 90
+			}
+		}
+		if err := pgm.Resize(13, 57, filter); err != nil {
+			t.Error(err)
+		}
+		if pgm.width != 13 || pgm.height != 57 {
+			t.Errorf("Filter %d: wrong size %dx%d", filter, pgm.width, pgm.height)
+		}
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				if pgm.data[y][x] != 90 {
+					t.Errorf("Filter %d: pixel at (%d, %d) changed to %d", filter, x, y, pgm.data[y][x])
+				}
+			}
+		}
+	}
+
+	pgm := NewPGM(2, 2, 255)
+	if err := pgm.Resize(0, 2, BilinearFilter); err == nil {
+		t.Error("Expected an error for an invalid size")
+	}
+}
+
+func TestResizeNearestPGM(t *testing.T) {
+	pgm, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	if err := pgm.Resize(imagePGMWidth*2, imagePGMHeight*3, NearestFilter); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			expected := testData[(y/3)*imagePGMWidth+x/2]
+			if pgm.data[y][x] != expected {
+				t.Errorf("Pixel at (%d, %d) not scaled correctly, expected %d, got %d", x, y, expected, pgm.data[y][x])
+			}
+		}
+	}
+}
+
+func TestResizeAreaPGM(t *testing.T) {
+	pgm := NewPGM(4, 2, 255)
+	pgm.data = [][]uint8{
+		{0, 100, 200, 200},
+		{100, 200, 0, 200},
+	}
+	if err := pgm.Resize(2, 1, AreaFilter); err != nil {
+		t.Error(err)
+	}
+	if pgm.data[0][0] != 100 || pgm.data[0][1] != 150 {
+		t.Errorf("Blocks not averaged correctly, got %v", pgm.data[0])
+	}
+}
+
+func TestResizeFitFillPGM(t *testing.T) {
+	pgm := NewPGM(40, 20, 255)
+	if err := pgm.ResizeFit(10, 10, BilinearFilter); err != nil {
+		t.Error(err)
+	}
+	if pgm.width != 10 || pgm.height != 5 {
+		t.Errorf("Wrong fitted size %dx%d", pgm.width, pgm.height)
+	}
+
+	pgm = NewPGM(40, 20, 255)
+	pgm.data[10][0] = 255
+	if err := pgm.ResizeFill(10, 10, NearestFilter); err != nil {
+		t.Error(err)
+	}
+	if pgm.width != 10 || pgm.height != 10 {
+		t.Errorf("Wrong filled size %dx%d", pgm.width, pgm.height)
+	}
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			if pgm.data[y][x] != 0 {
+				t.Error("Left border not cropped")
+			}
+		}
+	}
+}
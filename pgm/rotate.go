@@ -0,0 +1,12 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// Rotate rotates the PGM image by angle degrees counterclockwise around its center.
+// When expand is true the canvas grows to hold the whole rotated image, otherwise the size is kept.
+// Areas not covered by the source image take the fill value.
+func (pgm *PGM) Rotate(angle float64, interp Interpolation, expand bool, fill uint8) {
+	newWidth, newHeight, inverse := raster.Rotation(pgm.width, pgm.height, angle, expand)
+	pgm.data = pgm.warp(newWidth, newHeight, inverse, interp, fill)
+	pgm.width, pgm.height = newWidth, newHeight
+}
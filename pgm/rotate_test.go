@@ -0,0 +1,56 @@
+package Netpbm
+
+import "testing"
+
+func TestRotatePGM(t *testing.T) {
+	pgm, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	expected, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	expected.Rotate90CCW()
+	pgm.Rotate(90, NearestNeighbor, true, 0)
+	if pgm.width != expected.width || pgm.height != expected.height {
+		t.Errorf("Wrong size %dx%d", pgm.width, pgm.height)
+	}
+	for y := 0; y < expected.height; y++ {
+		for x := 0; x < expected.width; x++ {
+			if pgm.data[y][x] != expected.data[y][x] {
+				t.Errorf("Pixel at (%d, %d) not rotated correctly, expected %d, got %d", x, y, expected.data[y][x], pgm.data[y][x])
+			}
+		}
+	}
+}
+
+func TestRotateFillPGM(t *testing.T) {
+	for _, interp := range []Interpolation{NearestNeighbor, Bilinear, Bicubic} {
+		pgm := NewPGM(21, 11, 255)
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				pgm.data[y][x] = 100
+			}
+		}
+		pgm.Rotate(30, interp, true, 255)
+		if pgm.width != 24 || pgm.height != 21 {
+			t.Errorf("Interpolation %d: wrong expanded size %dx%d", interp, pgm.width, pgm.height)
+		}
+		if pgm.data[0][0] != 255 || pgm.data[pgm.height-1][pgm.width-1] != 255 {
+			t.Errorf("Interpolation %d: corners not filled", interp)
+		}
+		if pgm.data[pgm.height/2][pgm.width/2] != 100 {
+			t.Errorf("Interpolation %d: center changed to %d", interp, pgm.data[pgm.height/2][pgm.width/2])
+		}
+
+		pgm = NewPGM(20, 10, 255)
+		pgm.Rotate(-15, interp, false, 7)
+		if pgm.width != 20 || pgm.height != 10 {
+			t.Errorf("Interpolation %d: size not kept", interp)
+		}
+		if pgm.data[0][0] != 7 {
+			t.Errorf("Interpolation %d: corner not filled", interp)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package Netpbm
+
+import "testing"
+
+func TestGammaPGM(t *testing.T) {
+	pgm := newRowPGM(100, 0, 25, 100)
+	if err := pgm.Gamma(2); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Gamma", pgm, 0, 50, 100)
+	if err := pgm.Gamma(0); err == nil {
+		t.Error("Expected an error for a gamma of 0")
+	}
+	if _, err := GammaLUT(0, 2); err == nil {
+		t.Error("Expected an error for a max value of 0")
+	}
+	if _, err := GammaLUT(300, 1); err == nil {
+		t.Error("Expected an error for a max value above 255")
+	}
+	if _, err := BrightnessContrastLUT(-3, 0, 0); err == nil {
+		t.Error("Expected an error for a negative max value")
+	}
+}
+
+func TestBrightnessContrastPGM(t *testing.T) {
+	pgm := newRowPGM(200, 0, 50, 100, 200)
+	if err := pgm.BrightnessContrast(0.1, 0); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Brightness", pgm, 20, 70, 120, 200)
+
+	pgm = newRowPGM(200, 0, 50, 100, 150, 200)
+	if err := pgm.BrightnessContrast(0, -1); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "No contrast", pgm, 100, 100, 100, 100, 100)
+
+	pgm = newRowPGM(200, 0, 50, 99, 101, 200)
+	if err := pgm.BrightnessContrast(0, 1); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Full contrast", pgm, 0, 0, 0, 200, 200)
+
+	if err := pgm.BrightnessContrast(2, 0); err == nil {
+		t.Error("Expected an error for a brightness above 1")
+	}
+}
+
+func TestLevelsPGM(t *testing.T) {
+	pgm := newRowPGM(255, 0, 50, 100, 150, 255)
+	if err := pgm.Levels(50, 150, 1, 0, 200); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Levels", pgm, 0, 0, 100, 200, 200)
+
+	pgm = newRowPGM(255, 0, 255)
+	if err := pgm.Levels(0, 255, 1, 255, 0); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Inverting levels", pgm, 255, 0)
+
+	if err := pgm.Levels(100, 100, 1, 0, 255); err == nil {
+		t.Error("Expected an error for equal input levels")
+	}
+	if err := pgm.Levels(0, 255, 1, 0, 256); err == nil {
+		t.Error("Expected an error for an output level above max")
+	}
+}
+
+func TestCurvesPGM(t *testing.T) {
+	pgm := newRowPGM(255, 0, 64, 128, 192, 255)
+	if err := pgm.Curves([]PointF{{X: 255, Y: 255}, {X: 0, Y: 0}}); err != nil {
+		t.Error(err)
+	}
+	checkRow(t, "Identity curve", pgm, 0, 64, 128, 192, 255)
+
+	// An S curve darkens shadows and brightens highlights without overshooting.
+	lut, err := CurveLUT(255, []PointF{{X: 0, Y: 0}, {X: 64, Y: 32}, {X: 192, Y: 224}, {X: 255, Y: 255}})
+	if err != nil {
+		t.Error(err)
+	}
+	if lut[64] != 32 || lut[192] != 224 || lut[128] != 128 {
+		t.Errorf("Wrong S curve at control points: %d %d %d", lut[64], lut[128], lut[192])
+	}
+	for v := 1; v < len(lut); v++ {
+		if lut[v] < lut[v-1] {
+			t.Errorf("S curve decreases at %d", v)
+		}
+	}
+
+	// The curve is flat outside the control points.
+	lut, err = CurveLUT(255, []PointF{{X: 100, Y: 50}, {X: 200, Y: 150}})
+	if err != nil {
+		t.Error(err)
+	}
+	if lut[0] != 50 || lut[150] != 100 || lut[255] != 150 {
+		t.Errorf("Wrong curve ends: %d %d %d", lut[0], lut[150], lut[255])
+	}
+
+	if _, err := CurveLUT(255, []PointF{{X: 0, Y: 0}}); err == nil {
+		t.Error("Expected an error for a single control point")
+	}
+	if _, err := CurveLUT(255, []PointF{{X: 10, Y: 0}, {X: 10, Y: 20}}); err == nil {
+		t.Error("Expected an error for control points at the same input")
+	}
+	if err := pgm.ApplyLUT(make([]uint8, 10)); err == nil {
+		t.Error("Expected an error for a lookup table of the wrong size")
+	}
+}
@@ -0,0 +1,151 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAffineMatrixPGM(t *testing.T) {
+	m := TranslationMatrix(3, -2).Multiply(RotationMatrix(30)).Multiply(ScaleMatrix(2, 0.5))
+	inverse, err := m.Invert()
+	if err != nil {
+		t.Error(err)
+	}
+	p := inverse.Apply(m.Apply(PointF{X: 7, Y: 11}))
+	if math.Abs(p.X-7) > 1e-9 || math.Abs(p.Y-11) > 1e-9 {
+		t.Errorf("Inverse does not undo the matrix, got %v", p)
+	}
+	if _, err := ScaleMatrix(0, 1).Invert(); err == nil {
+		t.Error("Expected an error for a singular matrix")
+	}
+	if IdentityMatrix().Multiply(ShearMatrix(1, 0)) != ShearMatrix(1, 0) {
+		t.Error("Identity does not leave matrices unchanged")
+	}
+}
+
+func TestAffinePGM(t *testing.T) {
+	pgm, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	if err := pgm.Affine(TranslationMatrix(2, 1), imagePGMWidth, imagePGMHeight, NearestNeighbor, 99); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < imagePGMHeight; y++ {
+		for x := 0; x < imagePGMWidth; x++ {
+			expected := uint8(99)
+			if x >= 2 && y >= 1 {
+				expected = testData[(y-1)*imagePGMWidth+x-2]
+			}
+			if pgm.data[y][x] != expected {
+				t.Errorf("Pixel at (%d, %d) not translated correctly, expected %d, got %d", x, y, expected, pgm.data[y][x])
+			}
+		}
+	}
+
+	// A rotation around the center matches Rotate.
+	pgm, _ = ReadPGM("testP2.pgm")
+	rotated, _ := ReadPGM("testP2.pgm")
+	rotated.Rotate(30, Bilinear, false, 0)
+	center := TranslationMatrix(7, 7)
+	back := TranslationMatrix(-7, -7)
+	if err := pgm.Affine(center.Multiply(RotationMatrix(30)).Multiply(back), imagePGMWidth, imagePGMHeight, Bilinear, 0); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < imagePGMHeight; y++ {
+		for x := 0; x < imagePGMWidth; x++ {
+			d := int(pgm.data[y][x]) - int(rotated.data[y][x])
+			if d > 1 || d < -1 {
+				t.Errorf("Pixel at (%d, %d) differs from Rotate: %d and %d", x, y, pgm.data[y][x], rotated.data[y][x])
+			}
+		}
+	}
+
+	if err := pgm.Affine(ScaleMatrix(0, 0), 5, 5, Bicubic, 0); err == nil {
+		t.Error("Expected an error for a singular matrix")
+	}
+}
+
+func TestPerspectivePGM(t *testing.T) {
+	pgm, err := ReadPGM("testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	corners := [4]PointF{{X: 0, Y: 0}, {X: 14, Y: 0}, {X: 14, Y: 14}, {X: 0, Y: 14}}
+	if err := pgm.Perspective(corners, corners, imagePGMWidth, imagePGMHeight, Bicubic, 0); err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < imagePGMWidth*imagePGMHeight; i++ {
+		x := i % imagePGMWidth
+		y := i / imagePGMWidth
+		if pgm.data[y][x] != testData[i] {
+			t.Errorf("Pixel at (%d, %d) changed by an identity warp", x, y)
+		}
+	}
+
+	// Mapping the square onto a rectangle twice as wide is a plain scale.
+	pgm, _ = ReadPGM("testP2.pgm")
+	stretched := [4]PointF{{X: 0, Y: 0}, {X: 28, Y: 0}, {X: 28, Y: 14}, {X: 0, Y: 14}}
+	if err := pgm.Perspective(corners, stretched, 29, imagePGMHeight, NearestNeighbor, 0); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < imagePGMHeight; y++ {
+		for x := 0; x < 29; x += 2 {
+			if pgm.data[y][x] != testData[y*imagePGMWidth+x/2] {
+				t.Errorf("Pixel at (%d, %d) not stretched correctly", x, y)
+			}
+		}
+	}
+
+	collinear := [4]PointF{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}
+	if err := pgm.Perspective(collinear, corners, 5, 5, Bilinear, 0); err == nil {
+		t.Error("Expected an error for degenerate corners")
+	}
+}
+
+func TestPerspectiveConvergingPGM(t *testing.T) {
+	// A strongly converging trapezoid puts the horizon of the projection between the origin
+	// and the destination quad, where the homography denominator is negative.
+	src := [4]PointF{{X: 0, Y: 0}, {X: 99, Y: 0}, {X: 99, Y: 99}, {X: 0, Y: 99}}
+	quads := [][4]PointF{
+		{{X: 145, Y: 100}, {X: 155, Y: 100}, {X: 250, Y: 199}, {X: 50, Y: 199}},
+		{{X: 60, Y: 100}, {X: 240, Y: 100}, {X: 250, Y: 199}, {X: 50, Y: 199}},
+	}
+	for _, quad := range quads {
+		pgm := NewPGM(100, 100, 255)
+		for y := range pgm.data {
+			for x := range pgm.data[y] {
+				pgm.data[y][x] = 200
+			}
+		}
+		if err := pgm.Perspective(src, quad, 300, 300, NearestNeighbor, 0); err != nil {
+			t.Error(err)
+		}
+
+		missed := 0
+		for y := range pgm.data {
+			for x := range pgm.data[y] {
+				if insideQuad(quad, float64(x), float64(y)) && pgm.data[y][x] != 200 {
+					missed++
+				}
+				if pgm.data[y][x] != 0 && (y < 95 || y > 215) {
+					t.Errorf("Quad %v: pixel at (%d, %d) above or below the quad not filled", quad, x, y)
+				}
+			}
+		}
+		if missed > 0 {
+			t.Errorf("Quad %v: %d pixels inside the quad not covered", quad, missed)
+		}
+	}
+}
+
+// insideQuad reports whether (x, y) is inside the convex quad with clockwise corners.
+func insideQuad(quad [4]PointF, x, y float64) bool {
+	for i, a := range quad {
+		b := quad[(i+1)%4]
+		if (b.X-a.X)*(y-a.Y)-(b.Y-a.Y)*(x-a.X) < 0 {
+			return false
+		}
+	}
+	return true
+}
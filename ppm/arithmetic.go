@@ -0,0 +1,96 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Operation selects the pixelwise arithmetic operation applied by Arithmetic and ArithmeticConstant.
+type Operation = raster.Operation
+
+const (
+	// AddOperation adds the values.
+	AddOperation = raster.AddOperation
+	// SubtractOperation subtracts the second value from the first.
+	SubtractOperation = raster.SubtractOperation
+	// MultiplyOperation multiplies the values. Between two images the product is divided by max,
+	// so that max acts as 1; with a constant the value is multiplied by the constant as is.
+	MultiplyOperation = raster.MultiplyOperation
+	// DifferenceOperation takes the absolute difference of the values.
+	DifferenceOperation = raster.DifferenceOperation
+	// MinOperation keeps the smaller value.
+	MinOperation = raster.MinOperation
+	// MaxOperation keeps the larger value.
+	MaxOperation = raster.MaxOperation
+)
+
+// Overflow selects how arithmetic results outside the [0, max] range are brought back into it.
+type Overflow = raster.Overflow
+
+const (
+	// Saturate clips every result to the [0, max] range.
+	Saturate = raster.Saturate
+	// Scale maps all results linearly from the range spanning them and [0, max] onto [0, max],
+	// so that no difference between results is lost. Results already in range are left unchanged.
+	Scale = raster.Scale
+)
+
+// Arithmetic combines the PPM image with another one of the same size and max value, in the manner
+// of pnmarith: every channel value v becomes v op w, where w is the value of the same channel of the other
+// image at the same place.
+func (ppm *PPM) Arithmetic(operation Operation, other *PPM, overflow Overflow) error {
+	if other.width != ppm.width || other.height != ppm.height {
+		return fmt.Errorf("image size %dx%d does not match %dx%d", other.width, other.height, ppm.width, ppm.height)
+	}
+	if other.max != ppm.max {
+		return fmt.Errorf("max value %d does not match %d", other.max, ppm.max)
+	}
+	channels, operands := ppm.channels(), other.channels()
+	if err := raster.Arithmetic(channels[:], operands[:], operation, ppm.max, overflow); err != nil {
+		return err
+	}
+	ppm.setChannels(channels)
+	return nil
+}
+
+// ArithmeticConstant combines every channel value v of the PPM image with a constant: v becomes v op value.
+func (ppm *PPM) ArithmeticConstant(operation Operation, value float64, overflow Overflow) error {
+	channels := ppm.channels()
+	if err := raster.ArithmeticConstant(channels[:], operation, value, ppm.max, overflow); err != nil {
+		return err
+	}
+	ppm.setChannels(channels)
+	return nil
+}
+
+// Mean returns the pixelwise average of a stack of PPM images of the same size and max value,
+// which reduces noise in repeated shots of the same scene or extracts a background plate.
+func Mean(images ...*PPM) (*PPM, error) {
+	if len(images) == 0 {
+		return nil, errors.New("no images to average")
+	}
+	first := images[0]
+	for i, image := range images[1:] {
+		if image.width != first.width || image.height != first.height || image.max != first.max {
+			return nil, fmt.Errorf("image %d (%dx%d, max %d) does not match the first one (%dx%d, max %d)",
+				i+1, image.width, image.height, image.max, first.width, first.height, first.max)
+		}
+	}
+
+	var stacks [3][][][]uint8
+	for _, image := range images {
+		for c, channel := range image.channels() {
+			stacks[c] = append(stacks[c], channel)
+		}
+	}
+	mean := NewPPM(first.width, first.height)
+	mean.magicNumber, mean.max = first.magicNumber, first.max
+	var channels [3][][]uint8
+	for c := range channels {
+		channels[c] = raster.Mean(stacks[c], first.max)
+	}
+	mean.setChannels(channels)
+	return mean, nil
+}
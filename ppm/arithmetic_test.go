@@ -0,0 +1,60 @@
+package Netpbm
+
+import "testing"
+
+func TestArithmeticPPM(t *testing.T) {
+	ppm := newRowPPM(Pixel{0, 100, 200}, Pixel{255, 50, 10})
+	if err := ppm.Arithmetic(AddOperation, newRowPPM(Pixel{10, 100, 100}, Pixel{0, 0, 0}), Saturate); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Add", ppm, Pixel{10, 200, 255}, Pixel{255, 50, 10})
+
+	// Scaling is shared by the channels: the largest sum, 300, becomes 255.
+	ppm = newRowPPM(Pixel{0, 100, 200}, Pixel{255, 50, 10})
+	if err := ppm.Arithmetic(AddOperation, newRowPPM(Pixel{10, 100, 100}, Pixel{0, 0, 0}), Scale); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Scaled add", ppm, Pixel{9, 170, 255}, Pixel{217, 43, 9})
+
+	ppm = newRowPPM(Pixel{0, 100, 200}, Pixel{255, 50, 10})
+	if err := ppm.Arithmetic(DifferenceOperation, newRowPPM(Pixel{10, 100, 100}, Pixel{0, 60, 0}), Saturate); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Difference", ppm, Pixel{10, 0, 100}, Pixel{255, 10, 10})
+
+	ppm = newRowPPM(Pixel{0, 100, 255})
+	if err := ppm.Arithmetic(MultiplyOperation, newRowPPM(Pixel{255, 51, 128}), Saturate); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Multiply", ppm, Pixel{0, 20, 128})
+
+	ppm = newRowPPM(Pixel{0, 100, 200})
+	if err := ppm.ArithmeticConstant(SubtractOperation, 50, Saturate); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Subtract constant", ppm, Pixel{0, 50, 150})
+
+	if err := ppm.Arithmetic(MinOperation, NewPPM(2, 1), Saturate); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	other := newRowPPM(Pixel{})
+	other.max = 100
+	if err := ppm.Arithmetic(MinOperation, other, Saturate); err == nil {
+		t.Error("Expected an error for a max mismatch")
+	}
+}
+
+func TestMeanPPM(t *testing.T) {
+	mean, err := Mean(newRowPPM(Pixel{0, 10, 255}), newRowPPM(Pixel{30, 20, 255}), newRowPPM(Pixel{0, 31, 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPixels(t, "Mean", mean, Pixel{10, 20, 170})
+
+	if _, err := Mean(); err == nil {
+		t.Error("Expected an error for an empty stack")
+	}
+	if _, err := Mean(NewPPM(1, 1), NewPPM(1, 2)); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+}
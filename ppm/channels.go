@@ -0,0 +1,154 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+// Channel identifies a color channel of a PPM image.
+type Channel int
+
+const (
+	// Red is the first channel.
+	Red Channel = iota
+	// Green is the second channel.
+	Green
+	// Blue is the third channel.
+	Blue
+)
+
+// valid tells whether the channel is one of Red, Green and Blue.
+func (c Channel) valid() bool {
+	return c >= Red && c <= Blue
+}
+
+// SplitChannels returns the red, green and blue channels of the PPM image as PGM images
+// with the same size and max value.
+func (ppm *PPM) SplitChannels() (r, g, b *graymap.PGM) {
+	channels := ppm.channels()
+	var images [3]*graymap.PGM
+	for c := range images {
+		images[c] = ppm.channelPGM(channels[c])
+	}
+	return images[0], images[1], images[2]
+}
+
+// channelPGM copies a channel of the PPM image into a PGM image.
+func (ppm *PPM) channelPGM(channel [][]uint8) *graymap.PGM {
+	pgm := graymap.NewPGM(ppm.width, ppm.height, ppm.max)
+	for y, row := range channel {
+		for x, v := range row {
+			pgm.Set(x, y, v)
+		}
+	}
+	return pgm
+}
+
+// pgmChannel copies the pixels of a PGM image into a channel.
+func pgmChannel(pgm *graymap.PGM) [][]uint8 {
+	width, height := pgm.Size()
+	channel := raster.NewChannel(width, height)
+	for y, row := range channel {
+		for x := range row {
+			row[x] = pgm.At(x, y)
+		}
+	}
+	return channel
+}
+
+// Channel returns one channel of the PPM image as a PGM image with the same size and max value.
+func (ppm *PPM) Channel(c Channel) (*graymap.PGM, error) {
+	if !c.valid() {
+		return nil, fmt.Errorf("invalid channel: %d", c)
+	}
+	return ppm.channelPGM(ppm.channels()[c]), nil
+}
+
+// MergeChannels creates a PPM image from red, green and blue PGM images,
+// which must have the same size and max value.
+func MergeChannels(r, g, b *graymap.PGM) (*PPM, error) {
+	width, height := r.Size()
+	for _, pgm := range []*graymap.PGM{g, b} {
+		if w, h := pgm.Size(); w != width || h != height {
+			return nil, fmt.Errorf("channel sizes differ: %dx%d and %dx%d", width, height, w, h)
+		}
+		if pgm.Max() != r.Max() {
+			return nil, fmt.Errorf("channel max values differ: %d and %d", r.Max(), pgm.Max())
+		}
+	}
+	ppm := NewPPM(width, height)
+	ppm.max = r.Max()
+	ppm.setChannels([3][][]uint8{pgmChannel(r), pgmChannel(g), pgmChannel(b)})
+	return ppm, nil
+}
+
+// SetChannel replaces one channel of the PPM image by a PGM image with the same size and max value.
+func (ppm *PPM) SetChannel(c Channel, pgm *graymap.PGM) error {
+	if !c.valid() {
+		return fmt.Errorf("invalid channel: %d", c)
+	}
+	if width, height := pgm.Size(); width != ppm.width || height != ppm.height {
+		return fmt.Errorf("channel size %dx%d does not match the %dx%d image", width, height, ppm.width, ppm.height)
+	}
+	if pgm.Max() != ppm.max {
+		return fmt.Errorf("channel max value %d does not match the image max value %d", pgm.Max(), ppm.max)
+	}
+	channels := ppm.channels()
+	channels[c] = pgmChannel(pgm)
+	ppm.setChannels(channels)
+	return nil
+}
+
+// Swizzle reorders the channels of the PPM image: the new red, green and blue channels are copied
+// from the given channels. For instance Swizzle(Blue, Green, Red) turns RGB into BGR, and
+// Swizzle(Green, Green, Green) makes a gray image from the green channel.
+func (ppm *PPM) Swizzle(red, green, blue Channel) error {
+	order := [3]Channel{red, green, blue}
+	for _, c := range order {
+		if !c.valid() {
+			return errors.New("invalid channel order")
+		}
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			values := [3]uint8{p.R, p.G, p.B}
+			ppm.data[y][x] = Pixel{values[order[0]], values[order[1]], values[order[2]]}
+		}
+	}
+	return nil
+}
+
+// channels splits the PPM image into its red, green and blue channels.
+func (ppm *PPM) channels() [3][][]uint8 {
+	var channels [3][][]uint8
+	for c := range channels {
+		channels[c] = make([][]uint8, ppm.height)
+		for y := 0; y < ppm.height; y++ {
+			channels[c][y] = make([]uint8, ppm.width)
+		}
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			channels[0][y][x], channels[1][y][x], channels[2][y][x] = p.R, p.G, p.B
+		}
+	}
+	return channels
+}
+
+// setChannels replaces the pixels of the PPM image by the red, green and blue channels,
+// whose size becomes the size of the image.
+func (ppm *PPM) setChannels(channels [3][][]uint8) {
+	ppm.width, ppm.height = raster.ChannelSize(channels[0])
+	ppm.data = make([][]Pixel, ppm.height)
+	for y := range ppm.data {
+		ppm.data[y] = make([]Pixel, ppm.width)
+		for x := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{channels[0][y][x], channels[1][y][x], channels[2][y][x]}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package Netpbm
+
+import (
+	"testing"
+
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+func TestSplitMergeChannels(t *testing.T) {
+	ppm := newRowPPM(Pixel{1, 2, 3}, Pixel{4, 5, 6})
+	ppm.max = 100
+	r, g, b := ppm.SplitChannels()
+	for c, pgm := range []*graymap.PGM{r, g, b} {
+		if width, height := pgm.Size(); pgm.Max() != 100 || width != 2 || height != 1 {
+			t.Errorf("Channel %d has a wrong header", c)
+		}
+		if pgm.At(0, 0) != uint8(c+1) || pgm.At(1, 0) != uint8(c+4) {
+			t.Errorf("Channel %d has wrong values %d %d", c, pgm.At(0, 0), pgm.At(1, 0))
+		}
+	}
+
+	merged, err := MergeChannels(b, g, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.max != 100 {
+		t.Errorf("Merged image has max %d", merged.max)
+	}
+	checkPixels(t, "MergeChannels", merged, Pixel{3, 2, 1}, Pixel{6, 5, 4})
+
+	if _, err := MergeChannels(r, g, graymap.NewPGM(2, 1, 50)); err == nil {
+		t.Error("Expected an error for different max values")
+	}
+	if _, err := MergeChannels(r, g, graymap.NewPGM(3, 1, 100)); err == nil {
+		t.Error("Expected an error for different sizes")
+	}
+}
+
+func TestProcessChannel(t *testing.T) {
+	// A channel goes through a pgm operation and comes back into the image.
+	ppm := newRowPPM(Pixel{10, 20, 30}, Pixel{40, 50, 60})
+	r, g, b := ppm.SplitChannels()
+	r.Invert()
+	merged, err := MergeChannels(r, g, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPixels(t, "Inverted red", merged, Pixel{245, 20, 30}, Pixel{215, 50, 60})
+}
+
+func TestSetChannel(t *testing.T) {
+	ppm := newRowPPM(Pixel{1, 2, 3}, Pixel{4, 5, 6})
+	green, err := ppm.Channel(Green)
+	if err != nil {
+		t.Fatal(err)
+	}
+	green.Set(0, 0, 200)
+	if ppm.data[0][0].G != 2 {
+		t.Error("Channel shares its data with the image")
+	}
+	if err := ppm.SetChannel(Blue, green); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "SetChannel", ppm, Pixel{1, 2, 200}, Pixel{4, 5, 5})
+
+	if err := ppm.SetChannel(Channel(3), green); err == nil {
+		t.Error("Expected an error for an invalid channel")
+	}
+	if err := ppm.SetChannel(Red, graymap.NewPGM(1, 1, 255)); err == nil {
+		t.Error("Expected an error for a channel of a different size")
+	}
+}
+
+func TestSwizzle(t *testing.T) {
+	ppm := newRowPPM(Pixel{1, 2, 3})
+	if err := ppm.Swizzle(Blue, Green, Red); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Swizzle to BGR", ppm, Pixel{3, 2, 1})
+	if err := ppm.Swizzle(Green, Green, Green); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Swizzle to gray", ppm, Pixel{2, 2, 2})
+	if err := ppm.Swizzle(Red, Green, Channel(-1)); err == nil {
+		t.Error("Expected an error for an invalid channel")
+	}
+}
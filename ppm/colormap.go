@@ -0,0 +1,239 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+// Colormap maps gray levels to colors.
+// Entries are ordered from the darkest gray level to the brightest one.
+type Colormap []Pixel
+
+// ColorStop is a color placed at a position between 0 and 1 of a gradient.
+type ColorStop struct {
+	Position float64
+	Color    Pixel
+}
+
+// colormapSize is the number of entries of the built-in colormaps.
+const colormapSize = 256
+
+// NewGradient creates a colormap of 256 entries by linear interpolation between color stops.
+func NewGradient(stops []ColorStop) (Colormap, error) {
+	if len(stops) < 2 {
+		return nil, errors.New("a gradient needs at least two color stops")
+	}
+	sorted := make([]ColorStop, len(stops))
+	copy(sorted, stops)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+	for _, stop := range sorted {
+		if stop.Position < 0 || stop.Position > 1 {
+			return nil, fmt.Errorf("invalid color stop position: %v", stop.Position)
+		}
+	}
+
+	colormap := make(Colormap, colormapSize)
+	for i := range colormap {
+		t := float64(i) / float64(colormapSize-1)
+		colormap[i] = gradientAt(sorted, t)
+	}
+	return colormap, nil
+}
+
+// gradientAt returns the color of sorted color stops at position t.
+func gradientAt(stops []ColorStop, t float64) Pixel {
+	if t <= stops[0].Position {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if t > stops[i].Position {
+			continue
+		}
+		a, b := stops[i-1], stops[i]
+		span := b.Position - a.Position
+		if span == 0 {
+			return b.Color
+		}
+		f := (t - a.Position) / span
+		return Pixel{
+			R: lerpChannel(a.Color.R, b.Color.R, f),
+			G: lerpChannel(a.Color.G, b.Color.G, f),
+			B: lerpChannel(a.Color.B, b.Color.B, f),
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+// lerpChannel interpolates linearly between two channel values.
+func lerpChannel(a, b uint8, f float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*f + 0.5)
+}
+
+// evenStops spreads colors evenly over the [0, 1] range.
+func evenStops(colors ...Pixel) []ColorStop {
+	stops := make([]ColorStop, len(colors))
+	for i, c := range colors {
+		stops[i] = ColorStop{Position: float64(i) / float64(len(colors)-1), Color: c}
+	}
+	return stops
+}
+
+// mustGradient builds a gradient from stops known to be valid.
+func mustGradient(stops []ColorStop) Colormap {
+	colormap, err := NewGradient(stops)
+	if err != nil {
+		panic(err)
+	}
+	return colormap
+}
+
+// Viridis returns the perceptually uniform viridis colormap.
+func Viridis() Colormap {
+	return mustGradient(evenStops(
+		Pixel{0x44, 0x01, 0x54}, Pixel{0x48, 0x28, 0x78}, Pixel{0x3e, 0x49, 0x89}, Pixel{0x31, 0x68, 0x8e}, Pixel{0x26, 0x82, 0x8e},
+		Pixel{0x1f, 0x9e, 0x89}, Pixel{0x35, 0xb7, 0x79}, Pixel{0x6e, 0xce, 0x58}, Pixel{0xb5, 0xde, 0x2b}, Pixel{0xfd, 0xe7, 0x25},
+	))
+}
+
+// Inferno returns the perceptually uniform inferno colormap.
+func Inferno() Colormap {
+	return mustGradient(evenStops(
+		Pixel{0x00, 0x00, 0x04}, Pixel{0x1b, 0x0c, 0x41}, Pixel{0x4a, 0x0c, 0x6b}, Pixel{0x78, 0x1c, 0x6d}, Pixel{0xa5, 0x2c, 0x60},
+		Pixel{0xcf, 0x44, 0x46}, Pixel{0xed, 0x69, 0x25}, Pixel{0xfb, 0x9b, 0x06}, Pixel{0xf7, 0xd1, 0x3d}, Pixel{0xfc, 0xff, 0xa4},
+	))
+}
+
+// Magma returns the perceptually uniform magma colormap.
+func Magma() Colormap {
+	return mustGradient(evenStops(
+		Pixel{0x00, 0x00, 0x04}, Pixel{0x18, 0x0f, 0x3d}, Pixel{0x44, 0x0f, 0x76}, Pixel{0x72, 0x1f, 0x81}, Pixel{0x9e, 0x2f, 0x7f},
+		Pixel{0xcd, 0x40, 0x71}, Pixel{0xf1, 0x60, 0x5d}, Pixel{0xfd, 0x96, 0x68}, Pixel{0xfe, 0xca, 0x8d}, Pixel{0xfc, 0xfd, 0xbf},
+	))
+}
+
+// Plasma returns the perceptually uniform plasma colormap.
+func Plasma() Colormap {
+	return mustGradient(evenStops(
+		Pixel{0x0d, 0x08, 0x87}, Pixel{0x46, 0x03, 0x9f}, Pixel{0x72, 0x01, 0xa8}, Pixel{0x9c, 0x17, 0x9e}, Pixel{0xbd, 0x37, 0x86},
+		Pixel{0xd8, 0x57, 0x6b}, Pixel{0xed, 0x79, 0x53}, Pixel{0xfb, 0x9f, 0x3a}, Pixel{0xfd, 0xca, 0x26}, Pixel{0xf0, 0xf9, 0x21},
+	))
+}
+
+// Cividis returns the cividis colormap, designed for color vision deficiencies.
+func Cividis() Colormap {
+	return mustGradient(evenStops(
+		Pixel{0x00, 0x22, 0x4e}, Pixel{0x12, 0x35, 0x70}, Pixel{0x3b, 0x49, 0x6c}, Pixel{0x57, 0x5d, 0x6d}, Pixel{0x70, 0x71, 0x73},
+		Pixel{0x8a, 0x87, 0x79}, Pixel{0xa6, 0x9d, 0x75}, Pixel{0xc4, 0xb5, 0x6c}, Pixel{0xe4, 0xcf, 0x5b}, Pixel{0xfe, 0xe8, 0x38},
+	))
+}
+
+// Jet returns the legacy jet colormap (dark blue, blue, cyan, yellow, red, dark red).
+func Jet() Colormap {
+	return mustGradient([]ColorStop{
+		{0, Pixel{0, 0, 128}},
+		{0.125, Pixel{0, 0, 255}},
+		{0.375, Pixel{0, 255, 255}},
+		{0.625, Pixel{255, 255, 0}},
+		{0.875, Pixel{255, 0, 0}},
+		{1, Pixel{128, 0, 0}},
+	})
+}
+
+// Hot returns the legacy hot colormap (black, red, yellow, white).
+func Hot() Colormap {
+	return mustGradient([]ColorStop{
+		{0, Pixel{0, 0, 0}},
+		{0.375, Pixel{255, 0, 0}},
+		{0.75, Pixel{255, 255, 0}},
+		{1, Pixel{255, 255, 255}},
+	})
+}
+
+// Cool returns the legacy cool colormap (cyan to magenta).
+func Cool() Colormap {
+	return mustGradient(evenStops(Pixel{0, 255, 255}, Pixel{255, 0, 255}))
+}
+
+// Grayscale returns a gray ramp from black to white.
+func Grayscale() Colormap {
+	return mustGradient(evenStops(Pixel{0, 0, 0}, Pixel{255, 255, 255}))
+}
+
+// InvertedGrayscale returns a gray ramp from white to black.
+func InvertedGrayscale() Colormap {
+	return mustGradient(evenStops(Pixel{255, 255, 255}, Pixel{0, 0, 0}))
+}
+
+// ColormapByName returns the built-in colormap with the given name, such as "viridis" or "jet".
+func ColormapByName(name string) (Colormap, error) {
+	switch strings.ToLower(name) {
+	case "viridis":
+		return Viridis(), nil
+	case "inferno":
+		return Inferno(), nil
+	case "magma":
+		return Magma(), nil
+	case "plasma":
+		return Plasma(), nil
+	case "cividis":
+		return Cividis(), nil
+	case "jet":
+		return Jet(), nil
+	case "hot":
+		return Hot(), nil
+	case "cool":
+		return Cool(), nil
+	case "gray", "grayscale":
+		return Grayscale(), nil
+	case "inverted", "invertedgrayscale":
+		return InvertedGrayscale(), nil
+	}
+	return nil, fmt.Errorf("unknown colormap: %s", name)
+}
+
+// Legend renders the colormap as a vertical colorbar, brightest gray level at the top.
+func (colormap Colormap) Legend(width, height int) *PPM {
+	ppm := NewPPM(width, height)
+	if len(colormap) == 0 {
+		return ppm
+	}
+	for y := 0; y < height; y++ {
+		index := len(colormap) - 1
+		if height > 1 {
+			index = (height - 1 - y) * (len(colormap) - 1) / (height - 1)
+		}
+		for x := 0; x < width; x++ {
+			ppm.data[y][x] = colormap[index]
+		}
+	}
+	return ppm
+}
+
+// legendGap is the number of background columns between an image and its colorbar.
+const legendGap = 2
+
+// FalseColor maps the gray levels of a PGM image through a colormap.
+// When legendWidth is greater than zero a colorbar of that width is drawn on the right of the image.
+func FalseColor(pgm *graymap.PGM, colormap Colormap, legendWidth int) *PPM {
+	image := FromPGM(pgm, colormap)
+	if legendWidth <= 0 {
+		return image
+	}
+
+	ppm := NewPPM(image.width+legendGap+legendWidth, image.height)
+	ppm.max = image.max
+	for y := 0; y < image.height; y++ {
+		copy(ppm.data[y], image.data[y])
+	}
+	legend := colormap.Legend(legendWidth, image.height)
+	for y := 0; y < image.height; y++ {
+		copy(ppm.data[y][image.width+legendGap:], legend.data[y])
+	}
+	return ppm
+}
@@ -0,0 +1,96 @@
+package Netpbm
+
+import (
+	"testing"
+
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+func TestNewGradient(t *testing.T) {
+	colormap, err := NewGradient([]ColorStop{
+		{1, Pixel{255, 255, 255}},
+		{0, Pixel{0, 0, 0}},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(colormap) != 256 {
+		t.Errorf("Wrong colormap size, expected 256, got %d", len(colormap))
+	}
+	for i, c := range colormap {
+		if c != (Pixel{uint8(i), uint8(i), uint8(i)}) {
+			t.Errorf("Entry %d not interpolated correctly, got %v", i, c)
+		}
+	}
+
+	if _, err := NewGradient([]ColorStop{{0, Pixel{}}}); err == nil {
+		t.Error("Expected an error for a single color stop")
+	}
+	if _, err := NewGradient([]ColorStop{{0, Pixel{}}, {2, Pixel{}}}); err == nil {
+		t.Error("Expected an error for a position out of range")
+	}
+}
+
+func TestBuiltinColormaps(t *testing.T) {
+	names := []string{"viridis", "inferno", "magma", "plasma", "cividis", "jet", "hot", "cool", "gray", "inverted"}
+	for _, name := range names {
+		colormap, err := ColormapByName(name)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(colormap) != 256 {
+			t.Errorf("Colormap %s has %d entries", name, len(colormap))
+		}
+	}
+	if _, err := ColormapByName("rainbow"); err == nil {
+		t.Error("Expected an error for an unknown colormap")
+	}
+
+	viridis := Viridis()
+	if viridis[0] != (Pixel{0x44, 0x01, 0x54}) || viridis[255] != (Pixel{0xfd, 0xe7, 0x25}) {
+		t.Error("Viridis end points not set correctly")
+	}
+	hot := Hot()
+	if hot[0] != (Pixel{0, 0, 0}) || hot[255] != (Pixel{255, 255, 255}) {
+		t.Error("Hot end points not set correctly")
+	}
+}
+
+func TestFalseColor(t *testing.T) {
+	const width, height, max = 12, 4, 11
+	gray := graymap.NewPGM(width, height, max)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			gray.Set(x, y, uint8(x))
+		}
+	}
+	colormap := Jet()
+
+	ppm := FalseColor(gray, colormap, 0)
+	if ppm.width != width || ppm.height != height {
+		t.Error("Size not set correctly")
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			expected := colormap[x*255/max]
+			if ppm.data[y][x] != expected {
+				t.Errorf("Pixel at (%d, %d) not mapped correctly, expected %v, got %v", x, y, expected, ppm.data[y][x])
+			}
+		}
+	}
+
+	ppm = FalseColor(gray, colormap, 3)
+	if ppm.width != width+legendGap+3 {
+		t.Errorf("Width with legend not set correctly, got %d", ppm.width)
+	}
+	if ppm.data[0][ppm.width-1] != colormap[255] {
+		t.Error("Legend top not set to the brightest color")
+	}
+	if ppm.data[height-1][ppm.width-1] != colormap[0] {
+		t.Error("Legend bottom not set to the darkest color")
+	}
+	if ppm.data[0][width] != (Pixel{}) {
+		t.Error("Legend gap not left blank")
+	}
+}
@@ -0,0 +1,468 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// HSV represents a color by its hue in degrees [0, 360), and its saturation and value in [0, 1].
+type HSV struct {
+	H, S, V float64
+}
+
+// HSL represents a color by its hue in degrees [0, 360), and its saturation and lightness in [0, 1].
+type HSL struct {
+	H, S, L float64
+}
+
+// XYZ represents a color in the CIE 1931 XYZ space, with a luminance Y of 1 for white.
+type XYZ struct {
+	X, Y, Z float64
+}
+
+// Lab represents a color in the CIE L*a*b* space relative to the D65 white point,
+// with a lightness L from 0 to 100.
+type Lab struct {
+	L, A, B float64
+}
+
+// YCbCr represents a color by its luma and chroma components as in JPEG (full range ITU-R BT.601),
+// all from 0 to 255 with chroma centered on 128.
+type YCbCr struct {
+	Y, Cb, Cr float64
+}
+
+// D65 white point of the sRGB space.
+const (
+	whiteX = 0.95047
+	whiteY = 1.0
+	whiteZ = 1.08883
+)
+
+// Conversions from Pixel read channels on a scale of 0 to 255 as sRGB, and conversions
+// back to Pixel round and clamp to that scale.
+
+// HSV returns the pixel in the HSV space.
+func (p Pixel) HSV() HSV {
+	return rgbToHSV(p.unit())
+}
+
+// Pixel returns the color as a pixel.
+func (c HSV) Pixel() Pixel {
+	return unitPixel(c.rgb())
+}
+
+// HSL returns the pixel in the HSL space.
+func (p Pixel) HSL() HSL {
+	return rgbToHSL(p.unit())
+}
+
+// Pixel returns the color as a pixel.
+func (c HSL) Pixel() Pixel {
+	return unitPixel(c.rgb())
+}
+
+// XYZ returns the pixel in the CIE XYZ space.
+func (p Pixel) XYZ() XYZ {
+	return rgbToXYZ(p.unit())
+}
+
+// Pixel returns the color as a pixel.
+func (c XYZ) Pixel() Pixel {
+	return unitPixel(c.rgb())
+}
+
+// Lab returns the pixel in the CIE L*a*b* space.
+func (p Pixel) Lab() Lab {
+	return p.XYZ().Lab()
+}
+
+// Pixel returns the color as a pixel.
+func (c Lab) Pixel() Pixel {
+	return c.XYZ().Pixel()
+}
+
+// YCbCr returns the pixel in the YCbCr space.
+func (p Pixel) YCbCr() YCbCr {
+	r, g, b := float64(p.R), float64(p.G), float64(p.B)
+	return YCbCr{
+		Y:  0.299*r + 0.587*g + 0.114*b,
+		Cb: 128 - 0.168736*r - 0.331264*g + 0.5*b,
+		Cr: 128 + 0.5*r - 0.418688*g - 0.081312*b,
+	}
+}
+
+// Pixel returns the color as a pixel.
+func (c YCbCr) Pixel() Pixel {
+	return Pixel{
+		R: raster.ClampValue(c.Y+1.402*(c.Cr-128), 255),
+		G: raster.ClampValue(c.Y-0.344136*(c.Cb-128)-0.714136*(c.Cr-128), 255),
+		B: raster.ClampValue(c.Y+1.772*(c.Cb-128), 255),
+	}
+}
+
+// unit returns the channels of the pixel on a scale of 0 to 1.
+func (p Pixel) unit() (float64, float64, float64) {
+	return float64(p.R) / 255, float64(p.G) / 255, float64(p.B) / 255
+}
+
+// unitPixel returns the pixel of channels on a scale of 0 to 1.
+func unitPixel(r, g, b float64) Pixel {
+	return Pixel{raster.ClampValue(r*255, 255), raster.ClampValue(g*255, 255), raster.ClampValue(b*255, 255)}
+}
+
+// rgbToHSV converts channels on a scale of 0 to 1 to HSV.
+func rgbToHSV(r, g, b float64) HSV {
+	high, low := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	c := HSV{H: hue(r, g, b, high, low), V: high}
+	if high > 0 {
+		c.S = (high - low) / high
+	}
+	return c
+}
+
+// rgbToHSL converts channels on a scale of 0 to 1 to HSL.
+func rgbToHSL(r, g, b float64) HSL {
+	high, low := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	c := HSL{H: hue(r, g, b, high, low), L: (high + low) / 2}
+	if high > low {
+		c.S = (high - low) / (1 - math.Abs(high+low-1))
+	}
+	return c
+}
+
+// hue returns the hue in degrees of channels whose highest and lowest values are high and low.
+func hue(r, g, b, high, low float64) float64 {
+	chroma := high - low
+	if chroma == 0 {
+		return 0
+	}
+	var h float64
+	switch high {
+	case r:
+		h = math.Mod((g-b)/chroma, 6)
+	case g:
+		h = (b-r)/chroma + 2
+	default:
+		h = (r-g)/chroma + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// hueRGB returns the channels, on a scale of 0 to 1, of a hue with the given chroma,
+// lifted so that the lowest channel is low.
+func hueRGB(h, chroma, low float64) (float64, float64, float64) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	sector := h / 60
+	x := chroma * (1 - math.Abs(math.Mod(sector, 2)-1))
+	var r, g, b float64
+	switch int(sector) {
+	case 0:
+		r, g = chroma, x
+	case 1:
+		r, g = x, chroma
+	case 2:
+		g, b = chroma, x
+	case 3:
+		g, b = x, chroma
+	case 4:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	return r + low, g + low, b + low
+}
+
+// rgb returns the channels of the color on a scale of 0 to 1.
+func (c HSV) rgb() (float64, float64, float64) {
+	chroma := c.V * c.S
+	return hueRGB(c.H, chroma, c.V-chroma)
+}
+
+// rgb returns the channels of the color on a scale of 0 to 1.
+func (c HSL) rgb() (float64, float64, float64) {
+	chroma := (1 - math.Abs(2*c.L-1)) * c.S
+	return hueRGB(c.H, chroma, c.L-chroma/2)
+}
+
+// linearize undoes the sRGB transfer curve of a channel on a scale of 0 to 1.
+func linearize(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// compand applies the sRGB transfer curve to a linear channel.
+func compand(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// rgbToXYZ converts sRGB channels on a scale of 0 to 1 to XYZ.
+func rgbToXYZ(r, g, b float64) XYZ {
+	r, g, b = linearize(r), linearize(g), linearize(b)
+	return XYZ{
+		X: 0.4124564*r + 0.3575761*g + 0.1804375*b,
+		Y: 0.2126729*r + 0.7151522*g + 0.0721750*b,
+		Z: 0.0193339*r + 0.1191920*g + 0.9503041*b,
+	}
+}
+
+// linearRGB returns the linear sRGB channels of the color.
+func (c XYZ) linearRGB() (float64, float64, float64) {
+	return 3.2404542*c.X - 1.5371385*c.Y - 0.4985314*c.Z,
+		-0.9692660*c.X + 1.8760108*c.Y + 0.0415560*c.Z,
+		0.0556434*c.X - 0.2040259*c.Y + 1.0572252*c.Z
+}
+
+// rgb returns the sRGB channels of the color on a scale of 0 to 1.
+func (c XYZ) rgb() (float64, float64, float64) {
+	r, g, b := c.linearRGB()
+	return compand(r), compand(g), compand(b)
+}
+
+// Lab returns the color in the CIE L*a*b* space.
+func (c XYZ) Lab() Lab {
+	fx, fy, fz := labF(c.X/whiteX), labF(c.Y/whiteY), labF(c.Z/whiteZ)
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// XYZ returns the color in the CIE XYZ space.
+func (c Lab) XYZ() XYZ {
+	fy := (c.L + 16) / 116
+	return XYZ{
+		X: whiteX * labFInverse(fy+c.A/500),
+		Y: whiteY * labFInverse(fy),
+		Z: whiteZ * labFInverse(fy-c.B/200),
+	}
+}
+
+// labF is the compression function of the L*a*b* space.
+func labF(t float64) float64 {
+	const delta = 6.0 / 29
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29
+}
+
+// labFInverse undoes labF.
+func labFInverse(f float64) float64 {
+	const delta = 6.0 / 29
+	if f > delta {
+		return f * f * f
+	}
+	return 3 * delta * delta * (f - 4.0/29)
+}
+
+// DeltaE76 returns the CIE76 color difference: the distance between two colors in the L*a*b* space.
+// A difference around 2.3 is just noticeable.
+func DeltaE76(a, b Lab) float64 {
+	return math.Sqrt((a.L-b.L)*(a.L-b.L) + (a.A-b.A)*(a.A-b.A) + (a.B-b.B)*(a.B-b.B))
+}
+
+// DeltaE2000 returns the CIEDE2000 color difference, which corrects CIE76 for the lower
+// sensitivity of the eye to differences in saturated colors and for its hue dependence.
+func DeltaE2000(a, b Lab) float64 {
+	const pow25_7 = 6103515625 // 25^7
+	radians := math.Pi / 180
+
+	cBar := (math.Hypot(a.A, a.B) + math.Hypot(b.A, b.B)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cBar, 7)/(math.Pow(cBar, 7)+pow25_7)))
+	a1, a2 := (1+g)*a.A, (1+g)*b.A
+	c1, c2 := math.Hypot(a1, a.B), math.Hypot(a2, b.B)
+	h1, h2 := labHue(a1, a.B), labHue(a2, b.B)
+
+	dL := b.L - a.L
+	dC := c2 - c1
+	dh := 0.0
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(dh*radians/2)
+
+	lBar := (a.L + b.L) / 2
+	cBarPrime := (c1 + c2) / 2
+	hBar := h1 + h2
+	if c1*c2 != 0 {
+		switch {
+		case math.Abs(h1-h2) <= 180:
+			hBar /= 2
+		case h1+h2 < 360:
+			hBar = (hBar + 360) / 2
+		default:
+			hBar = (hBar - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos((hBar-30)*radians) + 0.24*math.Cos(2*hBar*radians) +
+		0.32*math.Cos((3*hBar+6)*radians) - 0.20*math.Cos((4*hBar-63)*radians)
+	dTheta := 30 * math.Exp(-((hBar-275)/25)*((hBar-275)/25))
+	rC := 2 * math.Sqrt(math.Pow(cBarPrime, 7)/(math.Pow(cBarPrime, 7)+pow25_7))
+	sL := 1 + 0.015*(lBar-50)*(lBar-50)/math.Sqrt(20+(lBar-50)*(lBar-50))
+	sC := 1 + 0.045*cBarPrime
+	sH := 1 + 0.015*cBarPrime*t
+	rT := -math.Sin(2*dTheta*radians) * rC
+
+	l, c, h := dL/sL, dC/sC, dH/sH
+	return math.Sqrt(l*l + c*c + h*h + rT*c*h)
+}
+
+// labHue returns the hue angle in degrees [0, 360) of the chroma components a and b.
+func labHue(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// mapColors replaces every pixel of the PPM image by the result of fn on its channels on a scale of 0 to 1.
+func (ppm *PPM) mapColors(fn func(r, g, b float64) (float64, float64, float64)) {
+	max := float64(ppm.max)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			r, g, b := fn(float64(p.R)/max, float64(p.G)/max, float64(p.B)/max)
+			ppm.data[y][x] = Pixel{raster.ClampValue(r*max, ppm.max), raster.ClampValue(g*max, ppm.max), raster.ClampValue(b*max, ppm.max)}
+		}
+	}
+}
+
+// RotateHue turns the hue of every pixel of the PPM image by the given angle in degrees.
+func (ppm *PPM) RotateHue(degrees float64) {
+	ppm.mapColors(func(r, g, b float64) (float64, float64, float64) {
+		c := rgbToHSL(r, g, b)
+		c.H += degrees
+		return c.rgb()
+	})
+}
+
+// AdjustSaturation multiplies the HSL saturation of every pixel of the PPM image by factor:
+// 0 gives grays, 1 changes nothing and higher values make colors more vivid.
+func (ppm *PPM) AdjustSaturation(factor float64) error {
+	if factor < 0 || math.IsNaN(factor) {
+		return fmt.Errorf("invalid saturation factor: %v", factor)
+	}
+	ppm.mapColors(func(r, g, b float64) (float64, float64, float64) {
+		c := rgbToHSL(r, g, b)
+		c.S = math.Min(1, c.S*factor)
+		return c.rgb()
+	})
+	return nil
+}
+
+// AdjustVibrance changes the saturation of the PPM image by amount, from -1 to 1, acting mostly on
+// moderately saturated colors: grays and already vivid colors are left nearly as they are.
+func (ppm *PPM) AdjustVibrance(amount float64) error {
+	if amount < -1 || amount > 1 || math.IsNaN(amount) {
+		return fmt.Errorf("invalid vibrance: %v", amount)
+	}
+	ppm.mapColors(func(r, g, b float64) (float64, float64, float64) {
+		c := rgbToHSL(r, g, b)
+		c.S += amount * c.S * (1 - c.S)
+		return c.rgb()
+	})
+	return nil
+}
+
+// scaleLinear multiplies the linear sRGB channels of every pixel of the PPM image.
+func (ppm *PPM) scaleLinear(factors [3]float64) {
+	ppm.mapColors(func(r, g, b float64) (float64, float64, float64) {
+		return compand(linearize(r) * factors[0]), compand(linearize(g) * factors[1]), compand(linearize(b) * factors[2])
+	})
+}
+
+// ColorTemperature rebalances the PPM image, balanced for light of temperature from, as if it had been
+// balanced for light of temperature to, both in kelvins between 1667 and 25000. Like the white balance
+// setting of a camera, lowering the temperature makes the image cooler (bluer) and raising it warmer.
+func (ppm *PPM) ColorTemperature(from, to float64) error {
+	for _, kelvin := range []float64{from, to} {
+		if kelvin < 1667 || kelvin > 25000 || math.IsNaN(kelvin) {
+			return fmt.Errorf("invalid color temperature: %v", kelvin)
+		}
+	}
+	fr, fg, fb := planckianWhite(from)
+	tr, tg, tb := planckianWhite(to)
+	ppm.scaleLinear([3]float64{fr / tr, fg / tg, fb / tb})
+	return nil
+}
+
+// planckianWhite returns the linear sRGB channels of the light of a black body at the given temperature,
+// with a luminance of 1, using the approximation of the Planckian locus by Kim et al.
+func planckianWhite(kelvin float64) (float64, float64, float64) {
+	t := kelvin
+	var x float64
+	if t <= 4000 {
+		x = -0.2661239e9/(t*t*t) - 0.2343589e6/(t*t) + 0.8776956e3/t + 0.179910
+	} else {
+		x = -3.0258469e9/(t*t*t) + 2.1070379e6/(t*t) + 0.2226347e3/t + 0.240390
+	}
+	var y float64
+	switch {
+	case t <= 2222:
+		y = -1.1063814*x*x*x - 1.34811020*x*x + 2.18555832*x - 0.20219683
+	case t <= 4000:
+		y = -0.9549476*x*x*x - 1.37418593*x*x + 2.09137015*x - 0.16748867
+	default:
+		y = 3.0817580*x*x*x - 5.87338670*x*x + 3.75112997*x - 0.37001483
+	}
+	return XYZ{X: x / y, Y: 1, Z: (1 - x - y) / y}.linearRGB()
+}
+
+// WhiteBalance scales the channels of the PPM image, in linear light, so that the given color,
+// taken from something known to be neutral, becomes a gray of the same luminance.
+func (ppm *PPM) WhiteBalance(white Pixel) error {
+	if white.R == 0 || white.G == 0 || white.B == 0 {
+		return errors.New("reference white must have no zero channel")
+	}
+	max := float64(ppm.max)
+	r, g, b := linearize(float64(white.R)/max), linearize(float64(white.G)/max), linearize(float64(white.B)/max)
+	luminance := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	ppm.scaleLinear([3]float64{luminance / r, luminance / g, luminance / b})
+	return nil
+}
+
+// AutoWhiteBalance balances the PPM image under the gray world assumption: the channels are scaled,
+// in linear light, so that the average color of the image becomes gray.
+func (ppm *PPM) AutoWhiteBalance() {
+	var sums [3]float64
+	max := float64(ppm.max)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			sums[0] += linearize(float64(p.R) / max)
+			sums[1] += linearize(float64(p.G) / max)
+			sums[2] += linearize(float64(p.B) / max)
+		}
+	}
+	luminance := 0.2126729*sums[0] + 0.7151522*sums[1] + 0.0721750*sums[2]
+	factors := [3]float64{1, 1, 1}
+	for c, sum := range sums {
+		if sum > 0 {
+			factors[c] = luminance / sum
+		}
+	}
+	ppm.scaleLinear(factors)
+}
@@ -0,0 +1,136 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestColorConversions(t *testing.T) {
+	red := Pixel{255, 0, 0}
+	if c := red.HSV(); c != (HSV{0, 1, 1}) {
+		t.Errorf("Wrong HSV %v", c)
+	}
+	if c := (Pixel{0, 128, 128}).HSL(); c.H != 180 || c.S != 1 || math.Abs(c.L-64.0/255) > 1e-12 {
+		t.Errorf("Wrong HSL %v", c)
+	}
+	if c := (Pixel{255, 255, 255}).XYZ(); math.Abs(c.X-whiteX) > 1e-4 || math.Abs(c.Y-1) > 1e-4 || math.Abs(c.Z-whiteZ) > 1e-4 {
+		t.Errorf("Wrong XYZ of white %v", c)
+	}
+	if c := red.Lab(); math.Abs(c.L-53.24) > 0.01 || math.Abs(c.A-80.09) > 0.01 || math.Abs(c.B-67.20) > 0.01 {
+		t.Errorf("Wrong Lab of red %v", c)
+	}
+	if c := (Pixel{255, 255, 255}).YCbCr(); math.Abs(c.Y-255) > 1e-9 || math.Abs(c.Cb-128) > 1e-9 || math.Abs(c.Cr-128) > 1e-9 {
+		t.Errorf("Wrong YCbCr of white %v", c)
+	}
+
+	// Every conversion goes back to the same pixel.
+	for _, p := range []Pixel{{0, 0, 0}, {255, 255, 255}, {12, 200, 99}, {250, 3, 128}, {77, 77, 78}, {1, 2, 254}} {
+		for name, q := range map[string]Pixel{
+			"HSV":   p.HSV().Pixel(),
+			"HSL":   p.HSL().Pixel(),
+			"XYZ":   p.XYZ().Pixel(),
+			"Lab":   p.Lab().Pixel(),
+			"YCbCr": p.YCbCr().Pixel(),
+		} {
+			if q != p {
+				t.Errorf("%s round trip of %v gave %v", name, p, q)
+			}
+		}
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	if d := DeltaE76(Lab{50, 10, 10}, Lab{53, 14, 10}); d != 5 {
+		t.Errorf("Wrong CIE76 difference %v", d)
+	}
+	// Reference values from Sharma, Wu and Dalal.
+	for _, c := range []struct {
+		a, b     Lab
+		expected float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 2.0425},
+		{Lab{50, 0, 0}, Lab{50, -1, 2}, 2.3669},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 27.1492},
+		{Lab{50, 2.49, -0.001}, Lab{50, -2.49, 0.0011}, 7.2195},
+		{Lab{90.8027, -2.0831, 1.4410}, Lab{91.1528, -1.6435, 0.0447}, 1.4441},
+	} {
+		if d := DeltaE2000(c.a, c.b); math.Abs(d-c.expected) > 1e-4 {
+			t.Errorf("CIEDE2000 of %v and %v: got %v, expected %v", c.a, c.b, d, c.expected)
+		}
+	}
+}
+
+func TestHueSaturation(t *testing.T) {
+	ppm := newRowPPM(Pixel{255, 0, 0}, Pixel{100, 100, 100})
+	ppm.RotateHue(120)
+	checkPixels(t, "RotateHue", ppm, Pixel{0, 255, 0}, Pixel{100, 100, 100})
+
+	ppm = newRowPPM(Pixel{200, 100, 100})
+	if err := ppm.AdjustSaturation(0); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "AdjustSaturation", ppm, Pixel{150, 150, 150})
+	if err := ppm.AdjustSaturation(-1); err == nil {
+		t.Error("Expected an error for a negative factor")
+	}
+
+	ppm = newRowPPM(Pixel{255, 0, 0}, Pixel{150, 100, 100}, Pixel{90, 90, 90})
+	if err := ppm.AdjustVibrance(1); err != nil {
+		t.Error(err)
+	}
+	if ppm.data[0][0] != (Pixel{255, 0, 0}) || ppm.data[0][2] != (Pixel{90, 90, 90}) {
+		t.Errorf("Vibrance changed saturated or gray pixels: %v", ppm.data[0])
+	}
+	if p := ppm.data[0][1]; p.HSL().S <= (Pixel{150, 100, 100}).HSL().S {
+		t.Errorf("Vibrance did not saturate %v", p)
+	}
+}
+
+func TestWhiteBalance(t *testing.T) {
+	ppm := newRowPPM(Pixel{200, 180, 150}, Pixel{100, 90, 75})
+	if err := ppm.WhiteBalance(Pixel{200, 180, 150}); err != nil {
+		t.Error(err)
+	}
+	if p := ppm.data[0][0]; p.R != p.G || p.G != p.B {
+		t.Errorf("Expected a gray, got %v", p)
+	}
+	// Colors scaled in sRGB are only nearly proportional in linear light.
+	if p := ppm.data[0][1]; math.Abs(float64(p.R)-float64(p.B)) > 2 || math.Abs(float64(p.G)-float64(p.B)) > 2 {
+		t.Errorf("Expected a near gray, got %v", p)
+	}
+	if err := ppm.WhiteBalance(Pixel{0, 10, 10}); err == nil {
+		t.Error("Expected an error for a white with a zero channel")
+	}
+
+	ppm = newRowPPM(Pixel{200, 100, 100}, Pixel{100, 150, 150})
+	ppm.AutoWhiteBalance()
+	r, g, b := 0.0, 0.0, 0.0
+	for _, p := range ppm.data[0] {
+		r += linearize(float64(p.R) / 255)
+		g += linearize(float64(p.G) / 255)
+		b += linearize(float64(p.B) / 255)
+	}
+	if math.Abs(r-g) > 0.02 || math.Abs(g-b) > 0.02 {
+		t.Errorf("Expected a gray average, got %v %v %v", r, g, b)
+	}
+}
+
+func TestColorTemperature(t *testing.T) {
+	ppm := newRowPPM(Pixel{128, 128, 128})
+	if err := ppm.ColorTemperature(6500, 3000); err != nil {
+		t.Error(err)
+	}
+	if p := ppm.data[0][0]; p.B <= p.R {
+		t.Errorf("Expected a cooler color, got %v", p)
+	}
+
+	ppm = newRowPPM(Pixel{128, 128, 128})
+	if err := ppm.ColorTemperature(5000, 5000); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Same temperature", ppm, Pixel{128, 128, 128})
+
+	if err := ppm.ColorTemperature(1000, 5000); err == nil {
+		t.Error("Expected an error for a temperature out of range")
+	}
+}
@@ -0,0 +1,228 @@
+package Netpbm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+// CompositeOperator selects the Porter-Duff operator combining a source image with a destination.
+type CompositeOperator int
+
+const (
+	// Over draws the source over the destination.
+	Over CompositeOperator = iota
+	// In keeps the source where the destination is.
+	In
+	// Out keeps the source where the destination is not.
+	Out
+	// Atop draws the source over the destination, only where the destination is.
+	Atop
+	// Xor keeps the source and the destination where they do not overlap.
+	Xor
+	// Source replaces the destination by the source.
+	Source
+	// Clear erases both.
+	Clear
+	// DestinationOver draws the destination over the source.
+	DestinationOver
+	// DestinationIn keeps the destination where the source is.
+	DestinationIn
+	// DestinationOut keeps the destination where the source is not.
+	DestinationOut
+	// DestinationAtop draws the destination over the source, only where the source is.
+	DestinationAtop
+)
+
+// BlendMode selects how the colors of the source and destination mix where they overlap.
+type BlendMode int
+
+const (
+	// Normal uses the source color.
+	Normal BlendMode = iota
+	// Multiply multiplies the colors, which always darkens.
+	Multiply
+	// Screen multiplies the complements of the colors, which always lightens.
+	Screen
+	// Overlay multiplies or screens the colors depending on the destination.
+	Overlay
+	// Darken keeps the darker of the colors.
+	Darken
+	// Lighten keeps the lighter of the colors.
+	Lighten
+	// Difference subtracts the darker of the colors from the lighter.
+	Difference
+	// SoftLight darkens or lightens the colors depending on the source, like a diffuse spotlight.
+	SoftLight
+)
+
+// Mask gives the opacity of every pixel of a source image while compositing, from 0 (transparent)
+// to 1 (opaque). PGMMask and PBMMask turn PGM and PBM images into masks.
+type Mask interface {
+	// Size returns the width and height of the mask.
+	Size() (int, int)
+	// Opacity returns the opacity of the pixel at (x, y).
+	Opacity(x, y int) float64
+}
+
+// pgmMask is a mask read from a PGM image.
+type pgmMask struct {
+	*graymap.PGM
+}
+
+// PGMMask returns a mask following pnmcomp: opaque where the PGM image is white (max)
+// and transparent where it is black.
+func PGMMask(pgm *graymap.PGM) Mask {
+	return pgmMask{pgm}
+}
+
+// Opacity returns the value of the pixel at (x, y) as a fraction of max.
+func (mask pgmMask) Opacity(x, y int) float64 {
+	if mask.Max() == 0 {
+		return 1
+	}
+	return math.Min(1, float64(mask.At(x, y))/float64(mask.Max()))
+}
+
+// pbmMask is a mask read from a PBM image.
+type pbmMask struct {
+	*bitmap.PBM
+}
+
+// PBMMask returns a mask following pnmcomp: opaque on unset (white) pixels of the PBM image
+// and transparent on set (black) ones.
+func PBMMask(pbm *bitmap.PBM) Mask {
+	return pbmMask{pbm}
+}
+
+// Opacity returns 1 if the pixel at (x, y) is unset, 0 otherwise.
+func (mask pbmMask) Opacity(x, y int) float64 {
+	if mask.At(x, y) {
+		return 0
+	}
+	return 1
+}
+
+// CompositeOptions sets how Composite combines two images.
+type CompositeOptions struct {
+	// Operator is the Porter-Duff operator, Over by default.
+	Operator CompositeOperator
+	// Blend is the blend mode, Normal by default.
+	Blend BlendMode
+	// Mask, if not nil, gives the opacity of every source pixel and must have the size of the source.
+	Mask Mask
+	// InvertMask makes the mask opaque where it would be transparent and conversely.
+	InvertMask bool
+	// Opacity scales the opacity of the whole source, from 0 (transparent) to 1 (opaque).
+	Opacity float64
+}
+
+// NewCompositeOptions returns the options drawing an opaque source over the destination.
+func NewCompositeOptions() CompositeOptions {
+	return CompositeOptions{Operator: Over, Blend: Normal, Opacity: 1}
+}
+
+// Composite combines the src image into dst with its top-left corner at the given point.
+// Only the part of dst covered by src changes, and parts of src outside dst are ignored.
+// The destination is opaque; operators leaving it partly transparent, such as In or Clear,
+// give colors flattened over black since PPM images have no alpha channel.
+// The two images may have different max values, but neither may be 0.
+func Composite(dst, src *PPM, at Point, opts CompositeOptions) error {
+	if opts.Operator < Over || opts.Operator > DestinationAtop {
+		return fmt.Errorf("invalid composite operator: %d", opts.Operator)
+	}
+	if opts.Blend < Normal || opts.Blend > SoftLight {
+		return fmt.Errorf("invalid blend mode: %d", opts.Blend)
+	}
+	opacity := opts.Opacity
+	if opacity < 0 || opacity > 1 || math.IsNaN(opacity) {
+		return fmt.Errorf("invalid opacity: %v", opts.Opacity)
+	}
+	if src.max == 0 || dst.max == 0 {
+		return fmt.Errorf("invalid max values: %d for the source, %d for the destination", src.max, dst.max)
+	}
+	if opts.Mask != nil {
+		if width, height := opts.Mask.Size(); width != src.width || height != src.height {
+			return fmt.Errorf("mask size %dx%d does not match the %dx%d source", width, height, src.width, src.height)
+		}
+	}
+
+	srcMax, dstMax := float64(src.max), float64(dst.max)
+	for y := max(0, -at.Y); y < src.height && at.Y+y < dst.height; y++ {
+		for x := max(0, -at.X); x < src.width && at.X+x < dst.width; x++ {
+			alpha := opacity
+			if opts.Mask != nil {
+				a := opts.Mask.Opacity(x, y)
+				if opts.InvertMask {
+					a = 1 - a
+				}
+				alpha *= a
+			}
+
+			s, d := src.data[y][x], &dst.data[at.Y+y][at.X+x]
+			channel := func(sv, dv uint8) uint8 {
+				cs, cd := float64(sv)/srcMax, float64(dv)/dstMax
+				return raster.ClampValue(porterDuff(opts.Operator, alpha, blend(opts.Blend, cd, cs), cd)*dstMax, dst.max)
+			}
+			*d = Pixel{channel(s.R, d.R), channel(s.G, d.G), channel(s.B, d.B)}
+		}
+	}
+	return nil
+}
+
+// porterDuff combines a source color of opacity alpha with an opaque destination color,
+// and returns the result premultiplied by its opacity.
+func porterDuff(operator CompositeOperator, alpha, source, destination float64) float64 {
+	switch operator {
+	case In, Source:
+		return alpha * source
+	case Out, Clear:
+		return 0
+	case Xor, DestinationOut:
+		return (1 - alpha) * destination
+	case DestinationOver:
+		return destination
+	case DestinationIn, DestinationAtop:
+		return alpha * destination
+	}
+	// Over and Atop are the same over an opaque destination.
+	return alpha*source + (1-alpha)*destination
+}
+
+// blend mixes a source color into a destination color, both from 0 to 1, as in the W3C compositing
+// specification.
+func blend(mode BlendMode, destination, source float64) float64 {
+	switch mode {
+	case Multiply:
+		return destination * source
+	case Screen:
+		return destination + source - destination*source
+	case Overlay:
+		// Hard light with the colors swapped.
+		if destination <= 0.5 {
+			return 2 * destination * source
+		}
+		return 1 - 2*(1-destination)*(1-source)
+	case Darken:
+		return math.Min(destination, source)
+	case Lighten:
+		return math.Max(destination, source)
+	case Difference:
+		return math.Abs(destination - source)
+	case SoftLight:
+		if source <= 0.5 {
+			return destination - (1-2*source)*destination*(1-destination)
+		}
+		var d float64
+		if destination <= 0.25 {
+			d = ((16*destination-12)*destination + 4) * destination
+		} else {
+			d = math.Sqrt(destination)
+		}
+		return destination + (2*source-1)*(d-destination)
+	}
+	return source
+}
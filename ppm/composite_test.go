@@ -0,0 +1,142 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+func TestCompositeOver(t *testing.T) {
+	dst := NewPPM(4, 3)
+	src := NewPPM(2, 2)
+	for y := range src.data {
+		for x := range src.data[y] {
+			src.data[y][x] = Pixel{200, 100, 0}
+		}
+	}
+
+	// The source is clipped to the destination.
+	if err := Composite(dst, src, Point{3, -1}, NewCompositeOptions()); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			expected := Pixel{}
+			if x == 3 && y == 0 {
+				expected = Pixel{200, 100, 0}
+			}
+			if dst.data[y][x] != expected {
+				t.Errorf("Pixel at (%d, %d): got %v, expected %v", x, y, dst.data[y][x], expected)
+			}
+		}
+	}
+
+	// Half opacity, with a PGM mask hiding the second column.
+	dst = newRowPPM(Pixel{0, 0, 100}, Pixel{0, 0, 100})
+	src = newRowPPM(Pixel{200, 100, 0}, Pixel{200, 100, 0})
+	mask := graymap.NewPGM(2, 1, 255)
+	mask.Set(0, 0, 255)
+	if err := Composite(dst, src, Point{0, 0}, CompositeOptions{Mask: PGMMask(mask), Opacity: 0.5}); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Masked composite", dst, Pixel{100, 50, 50}, Pixel{0, 0, 100})
+
+	// A PBM mask is opaque on unset pixels, unless inverted.
+	dst = newRowPPM(Pixel{0, 0, 0}, Pixel{0, 0, 0})
+	bits := bitmap.NewPBM(2, 1)
+	bits.Set(0, 0, true)
+	if err := Composite(dst, src, Point{0, 0}, CompositeOptions{Mask: PBMMask(bits), InvertMask: true, Opacity: 1}); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Inverted PBM mask", dst, Pixel{200, 100, 0}, Pixel{0, 0, 0})
+
+	// An opacity of 0 leaves the destination as is.
+	dst = newRowPPM(Pixel{0, 0, 100}, Pixel{0, 0, 100})
+	if err := Composite(dst, src, Point{0, 0}, CompositeOptions{}); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Transparent composite", dst, Pixel{0, 0, 100}, Pixel{0, 0, 100})
+}
+
+func TestCompositeOperators(t *testing.T) {
+	src := newRowPPM(Pixel{200, 200, 200})
+	for operator, expected := range map[CompositeOperator]Pixel{
+		Over:            {150, 150, 150},
+		In:              {100, 100, 100},
+		Out:             {0, 0, 0},
+		Atop:            {150, 150, 150},
+		Xor:             {50, 50, 50},
+		Source:          {100, 100, 100},
+		Clear:           {0, 0, 0},
+		DestinationOver: {100, 100, 100},
+		DestinationIn:   {50, 50, 50},
+		DestinationOut:  {50, 50, 50},
+		DestinationAtop: {50, 50, 50},
+	} {
+		dst := newRowPPM(Pixel{100, 100, 100})
+		if err := Composite(dst, src, Point{0, 0}, CompositeOptions{Operator: operator, Opacity: 0.5}); err != nil {
+			t.Error(err)
+		}
+		if dst.data[0][0] != expected {
+			t.Errorf("Operator %d: got %v, expected %v", operator, dst.data[0][0], expected)
+		}
+	}
+}
+
+func TestBlendModes(t *testing.T) {
+	for _, c := range []struct {
+		mode                BlendMode
+		destination, source float64
+		expected            float64
+	}{
+		{Normal, 0.2, 0.6, 0.6},
+		{Multiply, 0.5, 0.5, 0.25},
+		{Screen, 0.5, 0.5, 0.75},
+		{Overlay, 0.25, 0.5, 0.25},
+		{Overlay, 0.75, 0.5, 0.75},
+		{Darken, 0.2, 0.6, 0.2},
+		{Lighten, 0.2, 0.6, 0.6},
+		{Difference, 0.2, 0.6, 0.4},
+		{SoftLight, 0.25, 0.5, 0.25},
+		{SoftLight, 0.25, 1, 0.5},
+		{SoftLight, 0.5, 0, 0.25},
+	} {
+		if v := blend(c.mode, c.destination, c.source); math.Abs(v-c.expected) > 1e-12 {
+			t.Errorf("Blend %d of %v and %v: got %v, expected %v", c.mode, c.destination, c.source, v, c.expected)
+		}
+	}
+
+	dst := newRowPPM(Pixel{255, 128, 0})
+	src := newRowPPM(Pixel{128, 128, 128})
+	if err := Composite(dst, src, Point{0, 0}, CompositeOptions{Blend: Multiply, Opacity: 1}); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Multiply", dst, Pixel{128, 64, 0})
+}
+
+func TestCompositeErrors(t *testing.T) {
+	dst, src := NewPPM(2, 2), NewPPM(2, 2)
+	for _, opts := range []CompositeOptions{
+		{Opacity: 2},
+		{Operator: CompositeOperator(42)},
+		{Blend: BlendMode(-1)},
+		{Opacity: -0.5},
+		{Mask: PGMMask(graymap.NewPGM(3, 2, 255))},
+		{Mask: PBMMask(bitmap.NewPBM(2, 3))},
+	} {
+		if err := Composite(dst, src, Point{0, 0}, opts); err == nil {
+			t.Errorf("Expected an error for options %+v", opts)
+		}
+	}
+
+	src.max = 0
+	if err := Composite(dst, src, Point{0, 0}, NewCompositeOptions()); err == nil {
+		t.Error("Expected an error for a source with a max value of 0")
+	}
+	src.max, dst.max = 255, 0
+	if err := Composite(dst, src, Point{0, 0}, NewCompositeOptions()); err == nil {
+		t.Error("Expected an error for a destination with a max value of 0")
+	}
+}
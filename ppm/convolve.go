@@ -0,0 +1,84 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// Kernel is a convolution matrix applied around each pixel.
+// Data holds Height rows of Width weights; both sizes must be odd so the kernel has a center.
+// Divisor divides the weighted sum, zero meaning the sum of the weights (or 1 when they sum to zero),
+// and Bias is added to the result after the division.
+type Kernel = raster.Kernel
+
+// EdgeMode selects how pixels past the border of the image are read.
+type EdgeMode = raster.EdgeMode
+
+const (
+	// EdgeClamp repeats the border pixels.
+	EdgeClamp = raster.EdgeClamp
+	// EdgeMirror reflects the image across its border.
+	EdgeMirror = raster.EdgeMirror
+	// EdgeWrap reads the opposite side of the image.
+	EdgeWrap = raster.EdgeWrap
+	// EdgeZero treats pixels past the border as zero.
+	EdgeZero = raster.EdgeZero
+)
+
+// NewKernel creates a kernel from Height rows of Width weights.
+func NewKernel(width, height int, data []float64) (Kernel, error) {
+	return raster.NewKernel(width, height, data)
+}
+
+// BoxBlurKernel returns a (2*radius+1) square kernel averaging every pixel equally.
+// The radius must not be negative.
+func BoxBlurKernel(radius int) (Kernel, error) {
+	return raster.BoxBlurKernel(radius)
+}
+
+// GaussianKernel returns a normalized Gaussian blur kernel reaching three standard deviations.
+// The standard deviation must be positive and finite.
+func GaussianKernel(sigma float64) (Kernel, error) {
+	return raster.GaussianKernel(sigma)
+}
+
+// SharpenKernel returns a 3x3 kernel enhancing details.
+func SharpenKernel() Kernel {
+	return raster.SharpenKernel()
+}
+
+// EmbossKernel returns a 3x3 kernel giving a relief effect lit from the top left.
+func EmbossKernel() Kernel {
+	return raster.EmbossKernel()
+}
+
+// LaplacianKernel returns a 3x3 Laplacian kernel. Its weights sum to zero,
+// so set Bias to half the max value to see negative responses.
+func LaplacianKernel() Kernel {
+	return raster.LaplacianKernel()
+}
+
+// SobelXKernel returns the 3x3 Sobel kernel responding to horizontal changes.
+func SobelXKernel() Kernel {
+	return raster.SobelXKernel()
+}
+
+// SobelYKernel returns the 3x3 Sobel kernel responding to vertical changes.
+func SobelYKernel() Kernel {
+	return raster.SobelYKernel()
+}
+
+// LoadKernel reads a kernel from a text file.
+// Every line holds one row of weights separated by spaces, and every row must have the same length.
+// Lines "divisor <value>" and "bias <value>" set the divisor and the bias. Text after '#' is ignored.
+func LoadKernel(filename string) (Kernel, error) {
+	return raster.LoadKernel(filename)
+}
+
+// Convolve applies the kernel to each channel of the PPM image. The kernel is laid over each
+// pixel as written, without flipping, and results are clamped to [0, max].
+func (ppm *PPM) Convolve(kernel Kernel, edge EdgeMode) error {
+	channels := ppm.channels()
+	if err := raster.Convolve(channels[:], kernel, edge, ppm.max); err != nil {
+		return err
+	}
+	ppm.setChannels(channels)
+	return nil
+}
@@ -0,0 +1,37 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMConvolve(t *testing.T) {
+	ppm := NewPPM(5, 5)
+	ppm.Set(2, 2, Pixel{R: 90, G: 180, B: 9})
+	box, err := BoxBlurKernel(1)
+	if err != nil {
+		t.Error(err)
+	}
+	if err := ppm.Convolve(box, EdgeZero); err != nil {
+		t.Error(err)
+	}
+	for y := 1; y <= 3; y++ {
+		for x := 1; x <= 3; x++ {
+			if ppm.data[y][x] != (Pixel{10, 20, 1}) {
+				t.Errorf("Pixel at (%d, %d) not blurred correctly, got %v", x, y, ppm.data[y][x])
+			}
+		}
+	}
+	if ppm.data[0][0] != (Pixel{}) {
+		t.Error("Pixel out of the kernel reach changed")
+	}
+
+	ppm = newOrientationPPM()
+	if err := ppm.Convolve(SharpenKernel(), EdgeClamp); err != nil {
+		t.Error(err)
+	}
+	// Sharpening a pixel brighter than its neighbors makes it brighter still.
+	if ppm.data[1][2].B <= 18 {
+		t.Errorf("Pixel not sharpened, got %v", ppm.data[1][2])
+	}
+	if err := ppm.Convolve(Kernel{Width: 1, Height: 1}, EdgeClamp); err == nil {
+		t.Error("Expected an error for a kernel without weights")
+	}
+}
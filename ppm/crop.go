@@ -0,0 +1,93 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Rect represents a rectangular area of an image.
+type Rect = raster.Rect
+
+// Crop keeps only the area of the PPM image inside rect.
+func (ppm *PPM) Crop(rect Rect) error {
+	newData, err := raster.Crop(ppm.data, ppm.width, ppm.height, rect)
+	if err != nil {
+		return err
+	}
+	ppm.data = newData
+	ppm.width, ppm.height = rect.Width, rect.Height
+	return nil
+}
+
+// AutoCrop trims the borders of the PPM image that have the color of its top-left corner,
+// give or take tolerance. It returns the area that was kept.
+func (ppm *PPM) AutoCrop(tolerance int) (Rect, error) {
+	if ppm.width == 0 || ppm.height == 0 {
+		return Rect{}, nil
+	}
+	return ppm.AutoCropColor(ppm.data[0][0], tolerance)
+}
+
+// AutoCropColor trims the borders of the PPM image that have the given color, give or take
+// tolerance on every channel. It returns the area that was kept. An image made only of the
+// border color is left untouched.
+func (ppm *PPM) AutoCropColor(color Pixel, tolerance int) (Rect, error) {
+	isBorder := func(x, y int) bool {
+		p := ppm.data[y][x]
+		return channelDistance(p.R, color.R) <= tolerance &&
+			channelDistance(p.G, color.G) <= tolerance &&
+			channelDistance(p.B, color.B) <= tolerance
+	}
+	rect := Rect{Width: ppm.width, Height: ppm.height}
+	if trimmed, ok := raster.TrimBounds(ppm.width, ppm.height, isBorder); ok {
+		if err := ppm.Crop(trimmed); err != nil {
+			return Rect{}, err
+		}
+		rect = trimmed
+	}
+	return rect, nil
+}
+
+// Pad grows the canvas of the PPM image by the given number of pixels on each side,
+// filling the new area with the fill color.
+func (ppm *PPM) Pad(top, right, bottom, left int, fill Pixel) error {
+	return ppm.pad(top, right, bottom, left, func(x, y int) Pixel {
+		return fill
+	})
+}
+
+// PadReplicate grows the canvas of the PPM image by the given number of pixels on each side,
+// extending the edge pixels into the new area.
+func (ppm *PPM) PadReplicate(top, right, bottom, left int) error {
+	if ppm.width == 0 || ppm.height == 0 {
+		return errors.New("cannot replicate the edges of an empty image")
+	}
+	return ppm.pad(top, right, bottom, left, func(x, y int) Pixel {
+		return ppm.data[raster.ClampInt(y, 0, ppm.height-1)][raster.ClampInt(x, 0, ppm.width-1)]
+	})
+}
+
+// Margin adds a border of the same size on every side of the PPM image.
+func (ppm *PPM) Margin(size int, fill Pixel) error {
+	return ppm.Pad(size, size, size, size, fill)
+}
+
+// pad grows the canvas, asking outside for the color of every new pixel in source coordinates.
+func (ppm *PPM) pad(top, right, bottom, left int, outside func(x, y int) Pixel) error {
+	newData, err := raster.Pad(ppm.data, ppm.width, ppm.height, top, right, bottom, left, outside)
+	if err != nil {
+		return err
+	}
+	ppm.data = newData
+	ppm.width, ppm.height = ppm.width+left+right, ppm.height+top+bottom
+	return nil
+}
+
+// channelDistance returns the absolute difference between two channel values.
+func channelDistance(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
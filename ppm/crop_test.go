@@ -0,0 +1,64 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMCrop(t *testing.T) {
+	ppm := newOrientationPPM()
+	if err := ppm.Crop(Rect{X: 1, Y: 1, Width: 2, Height: 1}); err != nil {
+		t.Error(err)
+	}
+	if ppm.width != 2 || ppm.height != 1 || ppm.data[0][0] != (Pixel{5, 10, 15}) || ppm.data[0][1] != (Pixel{6, 12, 18}) {
+		t.Error("Image not cropped correctly")
+	}
+	if err := ppm.Crop(Rect{Width: 0, Height: 1}); err == nil {
+		t.Error("Expected an error for an empty area")
+	}
+}
+
+func TestPPMAutoCrop(t *testing.T) {
+	background := Pixel{R: 250, G: 250, B: 250}
+	ppm := NewPPM(12, 10)
+	ppm.DrawFilledRectangle(Point{0, 0}, 12, 10, background)
+	ppm.Set(11, 9, Pixel{R: 246, G: 250, B: 252})
+	ppm.DrawFilledRectangle(Point{2, 3}, 4, 2, Pixel{R: 255})
+
+	rect, err := ppm.AutoCrop(5)
+	if err != nil {
+		t.Error(err)
+	}
+	if rect != (Rect{X: 2, Y: 3, Width: 4, Height: 2}) {
+		t.Errorf("Wrong trimmed area %v", rect)
+	}
+
+	ppm = NewPPM(12, 10)
+	ppm.DrawFilledRectangle(Point{2, 3}, 4, 2, Pixel{R: 255})
+	rect, err = ppm.AutoCropColor(Pixel{R: 255}, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if rect != (Rect{Width: 12, Height: 10}) {
+		t.Errorf("Border color absent from the edges should keep the image, got %v", rect)
+	}
+}
+
+func TestPPMPad(t *testing.T) {
+	fill := Pixel{R: 1, G: 2, B: 3}
+	ppm := newOrientationPPM()
+	if err := ppm.Margin(2, fill); err != nil {
+		t.Error(err)
+	}
+	if ppm.width != 7 || ppm.height != 6 {
+		t.Errorf("Wrong size %dx%d", ppm.width, ppm.height)
+	}
+	if ppm.data[0][0] != fill || ppm.data[2][2] != (Pixel{1, 2, 3}) || ppm.data[5][6] != fill {
+		t.Error("Margin not added correctly")
+	}
+
+	ppm = newOrientationPPM()
+	if err := ppm.PadReplicate(0, 1, 1, 0); err != nil {
+		t.Error(err)
+	}
+	if ppm.data[2][3] != (Pixel{6, 12, 18}) || ppm.data[0][3] != (Pixel{3, 6, 9}) {
+		t.Error("Edges not replicated correctly")
+	}
+}
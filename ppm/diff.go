@@ -0,0 +1,88 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+// DiffSummary describes the differences between two images.
+// Changed is the number of pixels differing by more than the tolerance, Bounds the smallest rectangle
+// holding every changed pixel (empty if none changed), and MaxDelta the largest difference between
+// two values of a channel, over all pixels including tolerated ones.
+type DiffSummary = raster.DiffSummary
+
+// Diff compares two PPM images of the same size and max value. A pixel changed if any of its channel
+// values differ by more than tolerance. It returns a PPM image with a max value of 255 showing the changed
+// pixels in red over a dimmed gray copy of the first image, and a summary of the changes.
+func Diff(a, b *PPM, tolerance int) (*PPM, DiffSummary, error) {
+	if err := checkDiffHeaders(a.width, a.height, a.max, b.width, b.height, b.max); err != nil {
+		return nil, DiffSummary{}, err
+	}
+	first, second := a.channels(), b.channels()
+	return diff(first[:], second[:], a.max, tolerance)
+}
+
+// DiffPGM compares two PGM images of the same size and max value. A pixel changed if its values differ
+// by more than tolerance. It returns a PPM image with a max value of 255 showing the changed pixels
+// in red over a dimmed copy of the first image, and a summary of the changes.
+func DiffPGM(a, b *graymap.PGM, tolerance int) (*PPM, DiffSummary, error) {
+	width, height := a.Size()
+	otherWidth, otherHeight := b.Size()
+	if err := checkDiffHeaders(width, height, a.Max(), otherWidth, otherHeight, b.Max()); err != nil {
+		return nil, DiffSummary{}, err
+	}
+	return diff([][][]uint8{pgmChannel(a)}, [][][]uint8{pgmChannel(b)}, a.Max(), tolerance)
+}
+
+// DiffPBM compares two PBM images of the same size. It returns a PPM image with a max value of 255
+// showing the changed pixels in red over a dimmed copy of the first image, and a summary of the changes.
+// PBM pixels are either equal or not, so MaxDelta is 0 or 1 and a tolerance of 1 or more hides every
+// change; the tolerance is there so that the signature matches Diff and DiffPGM, and is usually 0.
+func DiffPBM(a, b *bitmap.PBM, tolerance int) (*PPM, DiffSummary, error) {
+	width, height := a.Size()
+	otherWidth, otherHeight := b.Size()
+	if err := checkDiffHeaders(width, height, 1, otherWidth, otherHeight, 1); err != nil {
+		return nil, DiffSummary{}, err
+	}
+	return diff([][][]uint8{pbmChannel(a)}, [][][]uint8{pbmChannel(b)}, 1, tolerance)
+}
+
+// checkDiffHeaders returns an error unless two images have the same size and max value.
+func checkDiffHeaders(width, height, max, otherWidth, otherHeight, otherMax int) error {
+	if width != otherWidth || height != otherHeight {
+		return fmt.Errorf("image sizes differ: %dx%d and %dx%d", width, height, otherWidth, otherHeight)
+	}
+	if max != otherMax {
+		return fmt.Errorf("max values differ: %d and %d", max, otherMax)
+	}
+	return nil
+}
+
+// diff compares two images given as channels and wraps the highlight in a PPM image.
+func diff(a, b [][][]uint8, max, tolerance int) (*PPM, DiffSummary, error) {
+	channels, summary, err := raster.Diff(a, b, max, tolerance)
+	if err != nil {
+		return nil, summary, err
+	}
+	highlight := NewPPM(0, 0)
+	highlight.setChannels(channels)
+	return highlight, summary, nil
+}
+
+// pbmChannel returns the pixels of a PBM image as a channel with a max value of 1:
+// set (black) pixels are 0 and unset (white) pixels are 1.
+func pbmChannel(pbm *bitmap.PBM) [][]uint8 {
+	width, height := pbm.Size()
+	channel := raster.NewChannel(width, height)
+	for y, row := range channel {
+		for x := range row {
+			if !pbm.At(x, y) {
+				row[x] = 1
+			}
+		}
+	}
+	return channel
+}
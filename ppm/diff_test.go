@@ -0,0 +1,125 @@
+package Netpbm
+
+import (
+	"testing"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+func TestDiffPPM(t *testing.T) {
+	a := newRowPPM(Pixel{90, 90, 90}, Pixel{30, 60, 90}, Pixel{0, 0, 0}, Pixel{200, 100, 0})
+	b := newRowPPM(Pixel{90, 90, 90}, Pixel{30, 64, 90}, Pixel{0, 0, 1}, Pixel{200, 100, 0})
+
+	highlight, summary, err := Diff(a, b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := DiffSummary{Changed: 2, Bounds: Rect{X: 1, Y: 0, Width: 2, Height: 1}, MaxDelta: 4}
+	if summary != expected {
+		t.Errorf("Summary: got %+v, expected %+v", summary, expected)
+	}
+	checkPixels(t, "Highlight", highlight, Pixel{30, 30, 30}, Pixel{255, 0, 0}, Pixel{255, 0, 0}, Pixel{33, 33, 33})
+
+	// The tolerance hides small changes, but not from the largest delta.
+	highlight, summary, _ = Diff(a, b, 1)
+	expected = DiffSummary{Changed: 1, Bounds: Rect{X: 1, Y: 0, Width: 1, Height: 1}, MaxDelta: 4}
+	if summary != expected {
+		t.Errorf("Summary with tolerance: got %+v, expected %+v", summary, expected)
+	}
+	checkPixels(t, "Highlight with tolerance", highlight, Pixel{30, 30, 30}, Pixel{255, 0, 0}, Pixel{0, 0, 0})
+
+	if _, _, err := Diff(a, NewPPM(4, 2), 0); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	b.max = 100
+	if _, _, err := Diff(a, b, 0); err == nil {
+		t.Error("Expected an error for a max mismatch")
+	}
+}
+
+func TestDiffPGM(t *testing.T) {
+	a, b := graymap.NewPGM(4, 3, 100), graymap.NewPGM(4, 3, 100)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			a.Set(x, y, 90)
+			b.Set(x, y, 90)
+		}
+	}
+	b.Set(1, 0, 95)
+	b.Set(2, 2, 70)
+	b.Set(3, 1, 88)
+
+	highlight, summary, err := DiffPGM(a, b, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := DiffSummary{Changed: 2, Bounds: Rect{X: 1, Y: 0, Width: 2, Height: 3}, MaxDelta: 20}
+	if summary != expected {
+		t.Errorf("Summary: got %+v, expected %+v", summary, expected)
+	}
+	red, gray := Pixel{255, 0, 0}, Pixel{77, 77, 77}
+	if highlight.max != 255 || highlight.data[0][1] != red || highlight.data[2][2] != red {
+		t.Errorf("Changed pixels are not highlighted: %v", highlight.data)
+	}
+	if highlight.data[1][3] != gray || highlight.data[0][0] != gray {
+		t.Errorf("Unchanged pixels are not dimmed: %v", highlight.data)
+	}
+
+	if _, summary, _ := DiffPGM(a, a, 0); summary != (DiffSummary{}) {
+		t.Errorf("Summary of identical images: got %+v", summary)
+	}
+	if _, _, err := DiffPGM(a, graymap.NewPGM(3, 4, 100), 0); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	if _, _, err := DiffPGM(a, graymap.NewPGM(4, 3, 255), 0); err == nil {
+		t.Error("Expected an error for a max mismatch")
+	}
+	if _, _, err := DiffPGM(a, b, -1); err == nil {
+		t.Error("Expected an error for a negative tolerance")
+	}
+}
+
+func TestDiffPBM(t *testing.T) {
+	a, b := bitmap.NewPBM(4, 3), bitmap.NewPBM(4, 3)
+	for _, p := range [][2]int{{0, 0}, {1, 1}, {2, 1}} {
+		a.Set(p[0], p[1], true)
+	}
+	for _, p := range [][2]int{{0, 0}, {1, 1}, {3, 2}} {
+		b.Set(p[0], p[1], true)
+	}
+
+	highlight, summary, err := DiffPBM(a, b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := DiffSummary{Changed: 2, Bounds: Rect{X: 2, Y: 1, Width: 2, Height: 2}, MaxDelta: 1}
+	if summary != expected {
+		t.Errorf("Summary: got %+v, expected %+v", summary, expected)
+	}
+	red, black, white := Pixel{255, 0, 0}, Pixel{0, 0, 0}, Pixel{85, 85, 85}
+	for _, c := range []struct {
+		x, y     int
+		expected Pixel
+	}{
+		{0, 0, black}, {1, 0, white}, {1, 1, black}, {2, 1, red}, {3, 2, red},
+	} {
+		if p := highlight.data[c.y][c.x]; p != c.expected {
+			t.Errorf("Pixel at (%d, %d): got %v, expected %v", c.x, c.y, p, c.expected)
+		}
+	}
+
+	// Every change is at most 1, so a tolerance of 1 hides them all.
+	if _, summary, _ := DiffPBM(a, b, 1); summary.Changed != 0 || summary.MaxDelta != 1 {
+		t.Errorf("Summary with a tolerance of 1: got %+v", summary)
+	}
+	if _, summary, _ := DiffPBM(a, a, 0); summary != (DiffSummary{}) {
+		t.Errorf("Summary of identical images: got %+v", summary)
+	}
+	if _, _, err := DiffPBM(a, bitmap.NewPBM(3, 4), 0); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	if _, _, err := DiffPBM(a, b, -1); err == nil {
+		t.Error("Expected an error for a negative tolerance")
+	}
+}
@@ -0,0 +1,61 @@
+package Netpbm
+
+import (
+	"github.com/dolobe/Netpbm/internal/raster"
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+// PrewittXKernel returns the 3x3 Prewitt kernel responding to horizontal changes.
+func PrewittXKernel() Kernel {
+	return raster.PrewittXKernel()
+}
+
+// PrewittYKernel returns the 3x3 Prewitt kernel responding to vertical changes.
+func PrewittYKernel() Kernel {
+	return raster.PrewittYKernel()
+}
+
+// ScharrXKernel returns the 3x3 Scharr kernel responding to horizontal changes.
+func ScharrXKernel() Kernel {
+	return raster.ScharrXKernel()
+}
+
+// ScharrYKernel returns the 3x3 Scharr kernel responding to vertical changes.
+func ScharrYKernel() Kernel {
+	return raster.ScharrYKernel()
+}
+
+// grayscale converts the PPM image to a PGM image with the same max value,
+// averaging the three channels of each pixel.
+func (ppm *PPM) grayscale() *graymap.PGM {
+	pgm := graymap.NewPGM(ppm.width, ppm.height, ppm.max)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			pgm.Set(x, y, uint8((int(p.R)+int(p.G)+int(p.B))/3))
+		}
+	}
+	return pgm
+}
+
+// Sobel converts the PPM image to grayscale and computes its gradient with the Sobel operator.
+func (ppm *PPM) Sobel() (magnitude, direction *graymap.PGM) {
+	return ppm.grayscale().Sobel()
+}
+
+// Prewitt converts the PPM image to grayscale and computes its gradient with the Prewitt operator.
+func (ppm *PPM) Prewitt() (magnitude, direction *graymap.PGM) {
+	return ppm.grayscale().Prewitt()
+}
+
+// Scharr converts the PPM image to grayscale and computes its gradient with the Scharr operator.
+func (ppm *PPM) Scharr() (magnitude, direction *graymap.PGM) {
+	return ppm.grayscale().Scharr()
+}
+
+// Canny converts the PPM image to grayscale and detects its edges with the Canny algorithm.
+// See the Canny method of PGM images for the parameters.
+func (ppm *PPM) Canny(sigma, low, high float64) (*bitmap.PBM, error) {
+	return ppm.grayscale().Canny(sigma, low, high)
+}
@@ -0,0 +1,35 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMEdges(t *testing.T) {
+	ppm := NewPPM(20, 20)
+	ppm.DrawFilledRectangle(Point{5, 5}, 10, 10, Pixel{R: 255, G: 255, B: 255})
+
+	magnitude, _ := ppm.Sobel()
+	if magnitude.At(4, 10) != 255 || magnitude.At(10, 10) != 0 {
+		t.Errorf("Wrong gradient magnitude %d %d", magnitude.At(4, 10), magnitude.At(10, 10))
+	}
+	magnitude, _ = ppm.Scharr()
+	if magnitude.At(5, 10) != 255 {
+		t.Errorf("Wrong Scharr magnitude %d", magnitude.At(5, 10))
+	}
+	magnitude, _ = ppm.Prewitt()
+	if magnitude.At(5, 10) != 255 {
+		t.Errorf("Wrong Prewitt magnitude %d", magnitude.At(5, 10))
+	}
+
+	edges, err := ppm.Canny(1, 0.1, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edges.At(10, 10) || edges.At(0, 0) {
+		t.Error("Flat areas should have no edges")
+	}
+	if !edges.At(4, 10) && !edges.At(5, 10) {
+		t.Error("Side of the square not detected")
+	}
+	if _, err := ppm.Canny(-1, 0.1, 0.3); err == nil {
+		t.Error("Canny should reject a negative sigma")
+	}
+}
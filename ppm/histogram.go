@@ -0,0 +1,63 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Histogram returns, for the red, green and blue channels of the PPM image, the number of pixels
+// for every value from 0 to max.
+func (ppm *PPM) Histogram() [3][]int {
+	var counts [3][]int
+	for c, channel := range ppm.channels() {
+		counts[c] = raster.Histogram(channel, ppm.max)
+	}
+	return counts
+}
+
+// Equalize spreads the values of every channel of the PPM image so that their histograms become
+// as flat as possible. Channels are equalized on their own, which may shift colors.
+func (ppm *PPM) Equalize() {
+	channels := ppm.channels()
+	raster.Equalize(channels[:], ppm.max)
+	ppm.setChannels(channels)
+}
+
+// CLAHE applies contrast-limited adaptive histogram equalization to every channel of the PPM image.
+// See the CLAHE method of PGM images for the meaning of the parameters.
+func (ppm *PPM) CLAHE(tilesX, tilesY int, clipLimit float64) error {
+	channels := ppm.channels()
+	if err := raster.CLAHE(channels[:], ppm.max, tilesX, tilesY, clipLimit); err != nil {
+		return err
+	}
+	ppm.setChannels(channels)
+	return nil
+}
+
+// MatchHistogram remaps every channel of the PPM image so that its histogram resembles the one
+// of the same channel of the reference image, which may have another size and max value.
+func (ppm *PPM) MatchHistogram(reference *PPM) error {
+	if reference.width*reference.height == 0 {
+		return errors.New("empty reference image")
+	}
+	channels, references := ppm.channels(), reference.Histogram()
+	for c := range channels {
+		raster.MatchHistogram(channels[c], ppm.max, references[c], reference.max)
+	}
+	ppm.setChannels(channels)
+	return nil
+}
+
+// AutoContrast stretches the values of the PPM image to the full range, in the manner of pnmnorm:
+// the darkest lowPercent of the channel values become 0, the brightest highPercent become max,
+// and values in between are spread linearly. The same stretch applies to the three channels
+// so that hues are kept.
+func (ppm *PPM) AutoContrast(lowPercent, highPercent float64) error {
+	channels := ppm.channels()
+	if err := raster.AutoContrast(channels[:], ppm.max, lowPercent, highPercent); err != nil {
+		return err
+	}
+	ppm.setChannels(channels)
+	return nil
+}
@@ -0,0 +1,83 @@
+package Netpbm
+
+import "testing"
+
+// newRowPPM creates a one-row PPM image holding the given pixels.
+func newRowPPM(pixels ...Pixel) *PPM {
+	ppm := NewPPM(len(pixels), 1)
+	copy(ppm.data[0], pixels)
+	return ppm
+}
+
+func TestHistogramPPM(t *testing.T) {
+	ppm := newRowPPM(Pixel{0, 10, 20}, Pixel{0, 30, 20})
+	ppm.max = 100
+	counts := ppm.Histogram()
+	if len(counts[0]) != 101 || counts[0][0] != 2 || counts[1][10] != 1 || counts[1][30] != 1 || counts[2][20] != 2 {
+		t.Errorf("Wrong histogram %v", counts)
+	}
+}
+
+func TestEqualizePPM(t *testing.T) {
+	ppm := newRowPPM(Pixel{10, 0, 5}, Pixel{20, 0, 5}, Pixel{30, 0, 6})
+	ppm.Equalize()
+	expected := []Pixel{{0, 0, 0}, {128, 0, 0}, {255, 0, 255}}
+	for x, p := range expected {
+		if ppm.data[0][x] != p {
+			t.Errorf("Equalize: got %v, expected %v", ppm.data[0], expected)
+			break
+		}
+	}
+}
+
+func TestAutoContrastPPM(t *testing.T) {
+	ppm := newRowPPM(Pixel{50, 100, 150}, Pixel{100, 75, 50})
+	if err := ppm.AutoContrast(0, 0); err != nil {
+		t.Error(err)
+	}
+	expected := []Pixel{{0, 128, 255}, {128, 64, 0}}
+	for x, p := range expected {
+		if ppm.data[0][x] != p {
+			t.Errorf("AutoContrast: got %v, expected %v", ppm.data[0], expected)
+			break
+		}
+	}
+	if err := ppm.AutoContrast(-1, 0); err == nil {
+		t.Error("Expected an error for a negative percentage")
+	}
+}
+
+func TestMatchHistogramPPM(t *testing.T) {
+	ppm := newRowPPM(Pixel{0, 5, 9}, Pixel{1, 6, 8})
+	reference := newRowPPM(Pixel{100, 50, 0}, Pixel{200, 50, 255})
+	if err := ppm.MatchHistogram(reference); err != nil {
+		t.Error(err)
+	}
+	expected := []Pixel{{100, 50, 255}, {200, 50, 0}}
+	for x, p := range expected {
+		if ppm.data[0][x] != p {
+			t.Errorf("MatchHistogram: got %v, expected %v", ppm.data[0], expected)
+			break
+		}
+	}
+}
+
+func TestCLAHEPPM(t *testing.T) {
+	ppm := NewPPM(16, 16)
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			v := uint8(100 + 10*((x+y)%2))
+			ppm.data[y][x] = Pixel{v, v, 50}
+		}
+	}
+	if err := ppm.CLAHE(2, 2, 4); err != nil {
+		t.Error(err)
+	}
+	a, b := ppm.data[5][5], ppm.data[5][6]
+	if a.R != a.G || int(b.R)-int(a.R) <= 10 {
+		t.Errorf("Expected a stronger contrast, got %v and %v", a, b)
+	}
+	if err := ppm.CLAHE(17, 2, 4); err == nil {
+		t.Error("Expected an error for more tiles than pixels")
+	}
+}
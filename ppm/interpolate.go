@@ -0,0 +1,24 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// Interpolation selects how pixel values are computed between pixel centers.
+type Interpolation = raster.Interpolation
+
+const (
+	// NearestNeighbor uses the value of the closest pixel.
+	NearestNeighbor = raster.NearestNeighbor
+	// Bilinear blends the 2x2 closest pixels.
+	Bilinear = raster.Bilinear
+	// Bicubic blends the 4x4 closest pixels with a cubic convolution kernel.
+	Bicubic = raster.Bicubic
+)
+
+// warp resamples the image onto a new width x height grid and makes it the size of the image.
+// For every destination pixel, inverse returns the matching source coordinates,
+// or false when the pixel has no source and must take the fill color.
+func (ppm *PPM) warp(width, height int, inverse func(x, y float64) (float64, float64, bool), interp Interpolation, fill Pixel) {
+	channels := ppm.channels()
+	warped := raster.Warp(channels[:], width, height, inverse, interp, ppm.max, []uint8{fill.R, fill.G, fill.B})
+	ppm.setChannels([3][][]uint8(warped))
+}
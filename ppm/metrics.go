@@ -0,0 +1,82 @@
+package Netpbm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+	graymap "github.com/dolobe/Netpbm/pgm"
+)
+
+// checkComparable returns an error unless the two PPM images have the same size and max value.
+func checkComparable(a, b *PPM) error {
+	if a.width != b.width || a.height != b.height {
+		return fmt.Errorf("image sizes differ: %dx%d and %dx%d", a.width, a.height, b.width, b.height)
+	}
+	if a.max != b.max {
+		return fmt.Errorf("max values differ: %d and %d", a.max, b.max)
+	}
+	if a.width*a.height == 0 {
+		return errors.New("empty images")
+	}
+	return nil
+}
+
+// MSE returns the mean squared error between two PPM images of the same size and max value,
+// for the red, green and blue channels and over all of them.
+func MSE(a, b *PPM) (channels [3]float64, combined float64, err error) {
+	if err := checkComparable(a, b); err != nil {
+		return channels, 0, err
+	}
+	first, second := a.channels(), b.channels()
+	for c := range channels {
+		channels[c] = raster.MSE(first[c], second[c])
+		combined += channels[c] / 3
+	}
+	return channels, combined, nil
+}
+
+// PSNR returns the peak signal-to-noise ratio in decibels between two PPM images of the same size
+// and max value, for the red, green and blue channels and over all of them, as pnmpsnr does:
+// 10 log10(max² / MSE). Identical channels give +Inf.
+func PSNR(a, b *PPM) (channels [3]float64, combined float64, err error) {
+	mse, combinedMSE, err := MSE(a, b)
+	if err != nil {
+		return channels, 0, err
+	}
+	for c := range channels {
+		channels[c] = raster.PSNR(mse[c], a.max)
+	}
+	return channels, raster.PSNR(combinedMSE, a.max), nil
+}
+
+// SSIM returns the structural similarity between two PPM images of the same size and max value,
+// averaged over the red, green and blue channels, and the map of the similarity of every pixel
+// as a PGM image with a max value of 255.
+// Statistics are gathered in a Gaussian window with a standard deviation of 1.5 pixels.
+// The similarity is 1 for identical images; the map shows negative similarities as black.
+func SSIM(a, b *PPM) (float64, *graymap.PGM, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, nil, err
+	}
+	first, second := a.channels(), b.channels()
+	similarity, similarityMap := raster.SSIM(first[:], second[:], a.max)
+	ssimMap := graymap.NewPGM(a.width, a.height, 255)
+	for y, row := range similarityMap {
+		for x, v := range row {
+			ssimMap.Set(x, y, v)
+		}
+	}
+	return similarity, ssimMap, nil
+}
+
+// MSSSIM returns the multi-scale structural similarity between two PPM images of the same size and
+// max value, averaged over the red, green and blue channels. The images are compared at five scales,
+// halving their size each time, so they must be at least 16 pixels wide and high.
+func MSSSIM(a, b *PPM) (float64, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, err
+	}
+	first, second := a.channels(), b.channels()
+	return raster.MSSSIM(first[:], second[:], a.max)
+}
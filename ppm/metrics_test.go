@@ -0,0 +1,77 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// newTexturePPM returns a 32x32 PPM image with smooth gradients and a checkered texture.
+func newTexturePPM() *PPM {
+	ppm := NewPPM(32, 32)
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			check := uint8(40 * ((x/4 + y/4) % 2))
+			ppm.data[y][x] = Pixel{uint8(4*x+2*y) + check, uint8(6*y) + check, uint8(200 - 3*x)}
+		}
+	}
+	return ppm
+}
+
+func TestMSEAndPSNRPPM(t *testing.T) {
+	a, b := newRowPPM(Pixel{0, 100, 200}, Pixel{50, 50, 50}), newRowPPM(Pixel{10, 100, 200}, Pixel{40, 50, 80})
+	channels, combined, err := MSE(a, b)
+	if err != nil || channels != [3]float64{100, 0, 450} || combined != 550.0/3 {
+		t.Errorf("MSE: got %v and %v (%v)", channels, combined, err)
+	}
+	psnrs, combinedPSNR, err := PSNR(a, b)
+	if err != nil || !math.IsInf(psnrs[1], 1) || math.Abs(psnrs[0]-10*math.Log10(255*255/100.0)) > 1e-9 ||
+		math.Abs(combinedPSNR-10*math.Log10(255*255/(550.0/3))) > 1e-9 {
+		t.Errorf("PSNR: got %v and %v (%v)", psnrs, combinedPSNR, err)
+	}
+
+	if _, _, err := MSE(a, NewPPM(1, 1)); err == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	b.max = 100
+	if _, _, err := PSNR(a, b); err == nil {
+		t.Error("Expected an error for a max mismatch")
+	}
+}
+
+func TestSSIMPPM(t *testing.T) {
+	a := newTexturePPM()
+	similarity, ssimMap, err := SSIM(a, a)
+	if err != nil || math.Abs(similarity-1) > 1e-9 {
+		t.Errorf("SSIM of identical images: got %v (%v), expected 1", similarity, err)
+	}
+	if width, height := ssimMap.Size(); width != 32 || height != 32 || ssimMap.Max() != 255 || ssimMap.At(5, 5) != 255 {
+		t.Error("Unexpected SSIM map of identical images")
+	}
+	if similarity, err := MSSSIM(a, a); err != nil || math.Abs(similarity-1) > 1e-9 {
+		t.Errorf("MS-SSIM of identical images: got %v (%v), expected 1", similarity, err)
+	}
+
+	noisy := NewPPM(32, 32)
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			p := a.data[y][x]
+			noise := (x*7+y*13)%21 - 10
+			noisy.data[y][x] = Pixel{
+				uint8(raster.ClampInt(int(p.R)+noise, 0, 255)),
+				uint8(raster.ClampInt(int(p.G)-noise, 0, 255)),
+				uint8(raster.ClampInt(int(p.B)+noise, 0, 255)),
+			}
+		}
+	}
+	if similarity, _, err := SSIM(a, noisy); err != nil || similarity >= 1 || similarity < 0.5 {
+		t.Errorf("SSIM with noise: got %v (%v)", similarity, err)
+	}
+	if similarity, err := MSSSIM(a, noisy); err != nil || similarity >= 1 || similarity < 0.5 {
+		t.Errorf("MS-SSIM with noise: got %v (%v)", similarity, err)
+	}
+	if _, err := MSSSIM(NewPPM(8, 8), NewPPM(8, 8)); err == nil {
+		t.Error("Expected an error for images too small")
+	}
+}
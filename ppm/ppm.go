@@ -10,6 +10,9 @@ import (
 	"os"
 	"sort"
 	"strconv"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
 )
 
 // PPM represents a PPM image.
@@ -216,6 +219,79 @@ func (ppm *PPM) Rotate90CW() {
 	ppm.data = newPPM.data
 }
 
+// Rotate90CCW rotates the PPM image 90° counterclockwise.
+func (ppm *PPM) Rotate90CCW() {
+	newPPM := NewPPM(ppm.height, ppm.width)
+
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			newPPM.data[ppm.width-x-1][y] = ppm.data[y][x]
+		}
+	}
+
+	ppm.width, ppm.height = newPPM.width, newPPM.height
+	ppm.data = newPPM.data
+}
+
+// Rotate180 rotates the PPM image 180°.
+func (ppm *PPM) Rotate180() {
+	ppm.Flip()
+	ppm.Flop()
+}
+
+// Transpose mirrors the PPM image along its main diagonal (top-left to bottom-right).
+func (ppm *PPM) Transpose() {
+	newPPM := NewPPM(ppm.height, ppm.width)
+
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			newPPM.data[x][y] = ppm.data[y][x]
+		}
+	}
+
+	ppm.width, ppm.height = newPPM.width, newPPM.height
+	ppm.data = newPPM.data
+}
+
+// Transverse mirrors the PPM image along its anti-diagonal (top-right to bottom-left).
+func (ppm *PPM) Transverse() {
+	newPPM := NewPPM(ppm.height, ppm.width)
+
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			newPPM.data[ppm.width-x-1][ppm.height-y-1] = ppm.data[y][x]
+		}
+	}
+
+	ppm.width, ppm.height = newPPM.width, newPPM.height
+	ppm.data = newPPM.data
+}
+
+// ApplyOrientation applies the transform described by an EXIF orientation code (1 to 8),
+// turning an image stored with that orientation into an upright one.
+func (ppm *PPM) ApplyOrientation(orientation int) error {
+	switch orientation {
+	case 1:
+	case 2:
+		ppm.Flip()
+	case 3:
+		ppm.Rotate180()
+	case 4:
+		ppm.Flop()
+	case 5:
+		ppm.Transpose()
+	case 6:
+		ppm.Rotate90CW()
+	case 7:
+		ppm.Transverse()
+	case 8:
+		ppm.Rotate90CCW()
+	default:
+		return fmt.Errorf("invalid orientation: %d", orientation)
+	}
+	return nil
+}
+
 // ToPGM converts the PPM image to PGM.
 func (ppm *PPM) ToPGM() *PGM {
 	// Create a new PGM image with the same size
@@ -249,6 +325,54 @@ func (ppm *PPM) ToPBM() *PBM {
 	return pbm
 }
 
+// FromPBM converts a PBM image to a PPM image with a max value of 255.
+// Set (black) pixels take the foreground color and unset pixels take the background color.
+func FromPBM(pbm *bitmap.PBM, foreground, background Pixel) *PPM {
+	width, height := pbm.Size()
+	ppm := NewPPM(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if pbm.At(x, y) {
+				ppm.data[y][x] = foreground
+			} else {
+				ppm.data[y][x] = background
+			}
+		}
+	}
+	return ppm
+}
+
+// FromPGM converts a PGM image to a PPM image.
+// Without a colormap every gray value is copied to the three channels and the max value is kept.
+// With a colormap the gray range [0, max] is spread over the colormap entries and the max value is 255.
+func FromPGM(pgm *graymap.PGM, colormap Colormap) *PPM {
+	width, height := pgm.Size()
+	ppm := NewPPM(width, height)
+	if len(colormap) == 0 {
+		ppm.max = pgm.Max()
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := pgm.At(x, y)
+			if len(colormap) == 0 {
+				ppm.data[y][x] = Pixel{value, value, value}
+				continue
+			}
+			index := 0
+			if pgm.Max() > 0 {
+				index = int(value) * (len(colormap) - 1) / pgm.Max()
+			}
+			if index >= len(colormap) {
+				index = len(colormap) - 1
+			}
+			ppm.data[y][x] = colormap[index]
+		}
+	}
+
+	return ppm
+}
+
 // DrawLine draws a line between two points.
 func (ppm *PPM) DrawLine(p1, p2 Point, color Pixel) {
 	// Use Bresenham's line algorithm to draw a line between two points.
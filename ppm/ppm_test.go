@@ -3,6 +3,9 @@ package Netpbm
 import (
 	"os"
 	"testing"
+
+	bitmap "github.com/dolobe/Netpbm/pbm"
+	graymap "github.com/dolobe/Netpbm/pgm"
 )
 
 const imagePPMWidth = 15
@@ -746,3 +749,132 @@ func TestPPMDrawFilledPolygon(t *testing.T) {
 		}
 	}
 }
+
+func TestPPMRotations(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(*PPM)
+		expected  [][]Pixel
+	}{
+		{"Rotate90CW", (*PPM).Rotate90CW, [][]Pixel{{{4, 8, 12}, {1, 2, 3}}, {{5, 10, 15}, {2, 4, 6}}, {{6, 12, 18}, {3, 6, 9}}}},
+		{"Rotate90CCW", (*PPM).Rotate90CCW, [][]Pixel{{{3, 6, 9}, {6, 12, 18}}, {{2, 4, 6}, {5, 10, 15}}, {{1, 2, 3}, {4, 8, 12}}}},
+		{"Rotate180", (*PPM).Rotate180, [][]Pixel{{{6, 12, 18}, {5, 10, 15}, {4, 8, 12}}, {{3, 6, 9}, {2, 4, 6}, {1, 2, 3}}}},
+		{"Transpose", (*PPM).Transpose, [][]Pixel{{{1, 2, 3}, {4, 8, 12}}, {{2, 4, 6}, {5, 10, 15}}, {{3, 6, 9}, {6, 12, 18}}}},
+		{"Transverse", (*PPM).Transverse, [][]Pixel{{{6, 12, 18}, {3, 6, 9}}, {{5, 10, 15}, {2, 4, 6}}, {{4, 8, 12}, {1, 2, 3}}}},
+	}
+	for _, test := range tests {
+		img := newOrientationPPM()
+		test.transform(img)
+		if img.width != len(test.expected[0]) || img.height != len(test.expected) {
+			t.Errorf("%s: wrong size %dx%d", test.name, img.width, img.height)
+			continue
+		}
+		for y := range test.expected {
+			for x := range test.expected[y] {
+				if img.data[y][x] != test.expected[y][x] {
+					t.Errorf("%s: pixel at (%d, %d) not transformed correctly", test.name, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestPPMApplyOrientation(t *testing.T) {
+	// The pixels of newOrientationPPM, numbered v for the color {v, 2v, 3v} and laid out as they must be
+	// after each EXIF orientation is applied.
+	expected := map[int][][]uint8{
+		1: {{1, 2, 3}, {4, 5, 6}},
+		2: {{3, 2, 1}, {6, 5, 4}},
+		3: {{6, 5, 4}, {3, 2, 1}},
+		4: {{4, 5, 6}, {1, 2, 3}},
+		5: {{1, 4}, {2, 5}, {3, 6}},
+		6: {{4, 1}, {5, 2}, {6, 3}},
+		7: {{6, 3}, {5, 2}, {4, 1}},
+		8: {{3, 6}, {2, 5}, {1, 4}},
+	}
+	for orientation := 1; orientation <= 8; orientation++ {
+		img := newOrientationPPM()
+		if err := img.ApplyOrientation(orientation); err != nil {
+			t.Error(err)
+		}
+		rows := expected[orientation]
+		if img.width != len(rows[0]) || img.height != len(rows) {
+			t.Errorf("Orientation %d: wrong size %dx%d", orientation, img.width, img.height)
+			continue
+		}
+		for y := range rows {
+			for x, v := range rows[y] {
+				if want := (Pixel{v, v * 2, v * 3}); img.data[y][x] != want {
+					t.Errorf("Orientation %d: pixel at (%d, %d) not transformed correctly, expected %v, got %v",
+						orientation, x, y, want, img.data[y][x])
+				}
+			}
+		}
+	}
+	img := newOrientationPPM()
+	if err := img.ApplyOrientation(9); err == nil {
+		t.Error("Expected an error for an invalid orientation")
+	}
+}
+
+// newOrientationPPM returns a 3x2 image with a distinct color in every pixel.
+func newOrientationPPM() *PPM {
+	ppm := NewPPM(3, 2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			v := uint8(y*3 + x + 1)
+			ppm.data[y][x] = Pixel{v, v * 2, v * 3}
+		}
+	}
+	return ppm
+}
+
+func TestFromPBM(t *testing.T) {
+	bits := bitmap.NewPBM(3, 2)
+	bits.Set(0, 0, true)
+	bits.Set(2, 1, true)
+	foreground := Pixel{R: 200, G: 10, B: 10}
+	background := Pixel{R: 10, G: 10, B: 200}
+	ppm := FromPBM(bits, foreground, background)
+	if ppm.magicNumber != "P3" {
+		t.Error("Wrong magic number")
+	}
+	if ppm.width != 3 || ppm.height != 2 || ppm.max != 255 {
+		t.Errorf("Wrong size or max value: %dx%d, max %d", ppm.width, ppm.height, ppm.max)
+	}
+	expected := [][]Pixel{{foreground, background, background}, {background, background, foreground}}
+	for y, row := range expected {
+		for x, pixel := range row {
+			if ppm.data[y][x] != pixel {
+				t.Errorf("Pixel at (%d, %d) not converted correctly, expected %v, got %v", x, y, pixel, ppm.data[y][x])
+			}
+		}
+	}
+}
+
+func TestFromPGM(t *testing.T) {
+	gray := graymap.NewPGM(3, 1, 11)
+	gray.Set(1, 0, 5)
+	gray.Set(2, 0, 11)
+
+	ppm := FromPGM(gray, nil)
+	if ppm.magicNumber != "P3" || ppm.max != 11 {
+		t.Errorf("Wrong magic number or max value: %s, %d", ppm.magicNumber, ppm.max)
+	}
+	for x, value := range []uint8{0, 5, 11} {
+		if ppm.data[0][x] != (Pixel{value, value, value}) {
+			t.Errorf("Pixel at (%d, 0) not set correctly, got %v", x, ppm.data[0][x])
+		}
+	}
+
+	colormap := Colormap{{0, 0, 255}, {255, 0, 0}}
+	ppm = FromPGM(gray, colormap)
+	if ppm.max != 255 {
+		t.Errorf("Wrong max value %d", ppm.max)
+	}
+	for x, expected := range []Pixel{colormap[0], colormap[0], colormap[1]} {
+		if ppm.data[0][x] != expected {
+			t.Errorf("Pixel at (%d, 0) not mapped correctly, expected %v, got %v", x, expected, ppm.data[0][x])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// WindowShape selects the neighborhood used by rank filters.
+type WindowShape = raster.WindowShape
+
+const (
+	// SquareWindow covers a (2*radius+1) square.
+	SquareWindow = raster.SquareWindow
+	// CircularWindow covers the pixels within radius of the center.
+	CircularWindow = raster.CircularWindow
+)
+
+// Median replaces every channel of every pixel of the PPM image by the median of its neighborhood,
+// which removes salt-and-pepper noise while keeping edges.
+func (ppm *PPM) Median(radius int, shape WindowShape) error {
+	return ppm.RankFilter(radius, 50, shape)
+}
+
+// MinFilter replaces every channel of every pixel of the PPM image by the lowest value of its neighborhood.
+func (ppm *PPM) MinFilter(radius int, shape WindowShape) error {
+	return ppm.RankFilter(radius, 0, shape)
+}
+
+// MaxFilter replaces every channel of every pixel of the PPM image by the highest value of its neighborhood.
+func (ppm *PPM) MaxFilter(radius int, shape WindowShape) error {
+	return ppm.RankFilter(radius, 100, shape)
+}
+
+// RankFilter replaces every channel of every pixel of the PPM image by the given percentile (0 to 100)
+// of its neighborhood, each channel being filtered on its own.
+// Neighborhoods are clipped at the border of the image.
+func (ppm *PPM) RankFilter(radius int, percentile float64, shape WindowShape) error {
+	channels := ppm.channels()
+	if err := raster.RankFilter(channels[:], radius, percentile, shape); err != nil {
+		return err
+	}
+	ppm.setChannels(channels)
+	return nil
+}
@@ -0,0 +1,38 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMRankFilter(t *testing.T) {
+	color := Pixel{R: 40, G: 90, B: 160}
+	ppm := NewPPM(7, 7)
+	ppm.DrawFilledRectangle(Point{0, 0}, 7, 7, color)
+	ppm.Set(3, 3, Pixel{R: 255, G: 0, B: 255})
+	ppm.Set(1, 5, Pixel{R: 0, G: 255, B: 0})
+	if err := ppm.Median(1, CircularWindow); err != nil {
+		t.Error(err)
+	}
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 7; x++ {
+			if ppm.data[y][x] != color {
+				t.Errorf("Noise left at (%d, %d): %v", x, y, ppm.data[y][x])
+			}
+		}
+	}
+
+	ppm.Set(3, 3, Pixel{R: 0, G: 200, B: 255})
+	if err := ppm.MaxFilter(1, SquareWindow); err != nil {
+		t.Error(err)
+	}
+	if ppm.data[2][2] != (Pixel{R: 40, G: 200, B: 255}) {
+		t.Errorf("Channels not filtered independently, got %v", ppm.data[2][2])
+	}
+	if err := ppm.MinFilter(1, SquareWindow); err != nil {
+		t.Error(err)
+	}
+	if ppm.data[3][3] != (Pixel{R: 40, G: 200, B: 255}) || ppm.data[1][1] != color {
+		t.Errorf("Min filter not applied correctly, got %v and %v", ppm.data[3][3], ppm.data[1][1])
+	}
+	if err := ppm.RankFilter(1, -5, SquareWindow); err == nil {
+		t.Error("Expected an error for a negative percentile")
+	}
+}
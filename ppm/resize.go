@@ -0,0 +1,61 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// Filter selects the resampling kernel used to resize an image.
+type Filter = raster.Filter
+
+const (
+	// NearestFilter copies the closest source pixel.
+	NearestFilter = raster.NearestFilter
+	// BilinearFilter uses a triangle kernel.
+	BilinearFilter = raster.BilinearFilter
+	// BicubicFilter uses the Catmull-Rom cubic kernel.
+	BicubicFilter = raster.BicubicFilter
+	// LanczosFilter uses a three-lobed Lanczos windowed sinc kernel.
+	LanczosFilter = raster.LanczosFilter
+	// AreaFilter averages the source pixels covered by each destination pixel, best for downscaling.
+	AreaFilter = raster.AreaFilter
+)
+
+// Resize scales the PPM image to width x height with the given resampling filter.
+// Rows are resampled horizontally, then columns vertically, in parallel.
+func (ppm *PPM) Resize(width, height int, filter Filter) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid size")
+	}
+	if ppm.width == 0 || ppm.height == 0 {
+		return errors.New("cannot resize an empty image")
+	}
+
+	channels := ppm.channels()
+	ppm.setChannels([3][][]uint8(raster.Resize(channels[:], width, height, filter, ppm.max)))
+	return nil
+}
+
+// ResizeFit scales the PPM image to fit inside maxWidth x maxHeight, preserving its aspect ratio.
+func (ppm *PPM) ResizeFit(maxWidth, maxHeight int, filter Filter) error {
+	if maxWidth <= 0 || maxHeight <= 0 {
+		return errors.New("invalid size")
+	}
+	width, height := raster.FitSize(ppm.width, ppm.height, maxWidth, maxHeight, false)
+	return ppm.Resize(width, height, filter)
+}
+
+// ResizeFill scales the PPM image to cover width x height, preserving its aspect ratio,
+// then crops the overflow evenly on both sides.
+func (ppm *PPM) ResizeFill(width, height int, filter Filter) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid size")
+	}
+	scaledWidth, scaledHeight := raster.FitSize(ppm.width, ppm.height, width, height, true)
+	if err := ppm.Resize(scaledWidth, scaledHeight, filter); err != nil {
+		return err
+	}
+
+	return ppm.Crop(Rect{X: (scaledWidth - width) / 2, Y: (scaledHeight - height) / 2, Width: width, Height: height})
+}
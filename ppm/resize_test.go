@@ -0,0 +1,55 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMResize(t *testing.T) {
+	color := Pixel{R: 30, G: 60, B: 200}
+	for _, filter := range []Filter{NearestFilter, BilinearFilter, BicubicFilter, LanczosFilter, AreaFilter} {
+		ppm := NewPPM(40, 30)
+		ppm.DrawFilledRectangle(Point{0, 0}, 40, 30, color)
+		if err := ppm.Resize(57, 13, filter); err != nil {
+			t.Error(err)
+		}
+		if ppm.width != 57 || ppm.height != 13 {
+			t.Errorf("Filter %d: wrong size %dx%d", filter, ppm.width, ppm.height)
+		}
+		for y := 0; y < ppm.height; y++ {
+			for x := 0; x < ppm.width; x++ {
+				if ppm.data[y][x] != color {
+					t.Errorf("Filter %d: pixel at (%d, %d) changed to %v", filter, x, y, ppm.data[y][x])
+				}
+			}
+		}
+	}
+}
+
+func TestPPMResizeArea(t *testing.T) {
+	ppm := NewPPM(2, 2)
+	ppm.data = [][]Pixel{
+		{{0, 0, 0}, {100, 0, 40}},
+		{{100, 200, 0}, {0, 200, 0}},
+	}
+	if err := ppm.Resize(1, 1, AreaFilter); err != nil {
+		t.Error(err)
+	}
+	if ppm.data[0][0] != (Pixel{50, 100, 10}) {
+		t.Errorf("Block not averaged correctly, got %v", ppm.data[0][0])
+	}
+}
+
+func TestPPMResizeFitFill(t *testing.T) {
+	ppm := NewPPM(30, 60)
+	if err := ppm.ResizeFit(20, 20, LanczosFilter); err != nil {
+		t.Error(err)
+	}
+	if ppm.width != 10 || ppm.height != 20 {
+		t.Errorf("Wrong fitted size %dx%d", ppm.width, ppm.height)
+	}
+	ppm = NewPPM(30, 60)
+	if err := ppm.ResizeFill(20, 20, BicubicFilter); err != nil {
+		t.Error(err)
+	}
+	if ppm.width != 20 || ppm.height != 20 {
+		t.Errorf("Wrong filled size %dx%d", ppm.width, ppm.height)
+	}
+}
@@ -0,0 +1,11 @@
+package Netpbm
+
+import "github.com/dolobe/Netpbm/internal/raster"
+
+// Rotate rotates the PPM image by angle degrees counterclockwise around its center.
+// When expand is true the canvas grows to hold the whole rotated image, otherwise the size is kept.
+// Areas not covered by the source image take the fill color.
+func (ppm *PPM) Rotate(angle float64, interp Interpolation, expand bool, fill Pixel) {
+	newWidth, newHeight, inverse := raster.Rotation(ppm.width, ppm.height, angle, expand)
+	ppm.warp(newWidth, newHeight, inverse, interp, fill)
+}
@@ -0,0 +1,45 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMRotate(t *testing.T) {
+	ppm, err := ReadPPM("testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	expected, err := ReadPPM("testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	expected.Rotate90CCW()
+	ppm.Rotate(90, NearestNeighbor, true, Pixel{})
+	if ppm.width != expected.width || ppm.height != expected.height {
+		t.Errorf("Wrong size %dx%d", ppm.width, ppm.height)
+	}
+	for y := 0; y < expected.height; y++ {
+		for x := 0; x < expected.width; x++ {
+			if ppm.data[y][x] != expected.data[y][x] {
+				t.Errorf("Pixel at (%d, %d) not rotated correctly, expected %v, got %v", x, y, expected.data[y][x], ppm.data[y][x])
+			}
+		}
+	}
+}
+
+func TestPPMRotateFill(t *testing.T) {
+	color := Pixel{R: 10, G: 120, B: 240}
+	fill := Pixel{R: 255, G: 0, B: 255}
+	for _, interp := range []Interpolation{NearestNeighbor, Bilinear, Bicubic} {
+		ppm := NewPPM(21, 11)
+		ppm.DrawFilledRectangle(Point{0, 0}, 21, 11, color)
+		ppm.Rotate(30, interp, true, fill)
+		if ppm.width != 24 || ppm.height != 21 {
+			t.Errorf("Interpolation %d: wrong expanded size %dx%d", interp, ppm.width, ppm.height)
+		}
+		if ppm.data[0][0] != fill || ppm.data[ppm.height-1][ppm.width-1] != fill {
+			t.Errorf("Interpolation %d: corners not filled", interp)
+		}
+		if ppm.data[ppm.height/2][ppm.width/2] != color {
+			t.Errorf("Interpolation %d: center changed to %v", interp, ppm.data[ppm.height/2][ppm.width/2])
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// ApplyLUT replaces every channel value v of the PPM image by lut[v]. The lookup table must have max+1 entries.
+func (ppm *PPM) ApplyLUT(lut []uint8) error {
+	return ppm.ApplyChannelLUTs(lut, lut, lut)
+}
+
+// ApplyChannelLUTs replaces the red, green and blue values of the PPM image through their own lookup table,
+// for instance to correct a color cast with a curve per channel. Every table must have max+1 entries.
+func (ppm *PPM) ApplyChannelLUTs(red, green, blue []uint8) error {
+	luts := [3][]uint8{red, green, blue}
+	for _, lut := range luts {
+		if len(lut) != ppm.max+1 {
+			return fmt.Errorf("lookup table has %d entries, expected %d", len(lut), ppm.max+1)
+		}
+	}
+	channels := ppm.channels()
+	for c := range channels {
+		raster.ApplyLUT(channels[c], luts[c])
+	}
+	ppm.setChannels(channels)
+	return nil
+}
+
+// Gamma applies a gamma correction to every channel of the PPM image. See GammaLUT.
+func (ppm *PPM) Gamma(gamma float64) error {
+	lut, err := GammaLUT(ppm.max, gamma)
+	if err != nil {
+		return err
+	}
+	return ppm.ApplyLUT(lut)
+}
+
+// BrightnessContrast adjusts the brightness and contrast of every channel of the PPM image.
+// See BrightnessContrastLUT.
+func (ppm *PPM) BrightnessContrast(brightness, contrast float64) error {
+	lut, err := BrightnessContrastLUT(ppm.max, brightness, contrast)
+	if err != nil {
+		return err
+	}
+	return ppm.ApplyLUT(lut)
+}
+
+// Levels adjusts the levels of every channel of the PPM image. See LevelsLUT.
+func (ppm *PPM) Levels(inBlack, inWhite int, gamma float64, outBlack, outWhite int) error {
+	lut, err := LevelsLUT(ppm.max, inBlack, inWhite, gamma, outBlack, outWhite)
+	if err != nil {
+		return err
+	}
+	return ppm.ApplyLUT(lut)
+}
+
+// Curves remaps every channel of the PPM image along a curve through control points. See CurveLUT.
+func (ppm *PPM) Curves(points []PointF) error {
+	lut, err := CurveLUT(ppm.max, points)
+	if err != nil {
+		return err
+	}
+	return ppm.ApplyLUT(lut)
+}
+
+// GammaLUT returns the lookup table of a gamma correction for values up to max:
+// v becomes max * (v/max)^(1/gamma), so a gamma above 1 brightens the midtones and below 1 darkens them.
+// The max value must be from 1 to 255.
+func GammaLUT(max int, gamma float64) ([]uint8, error) {
+	return raster.GammaLUT(max, gamma)
+}
+
+// BrightnessContrastLUT returns the lookup table adjusting brightness and contrast for values up to max.
+// Both range from -1 to 1. Brightness shifts values by a fraction of max. Contrast scales values around
+// the middle of the range: -1 gives a flat gray, 0 changes nothing and 1 gives a threshold.
+// The max value must be from 1 to 255.
+func BrightnessContrastLUT(max int, brightness, contrast float64) ([]uint8, error) {
+	return raster.BrightnessContrastLUT(max, brightness, contrast)
+}
+
+// LevelsLUT returns the lookup table of a levels adjustment for values up to max: values from inBlack
+// to inWhite are stretched to the [0, 1] range and clipped outside of it, raised to 1/gamma,
+// then spread from outBlack to outWhite. outBlack may be above outWhite to invert the image.
+// The max value must be from 1 to 255.
+func LevelsLUT(max, inBlack, inWhite int, gamma float64, outBlack, outWhite int) ([]uint8, error) {
+	return raster.LevelsLUT(max, inBlack, inWhite, gamma, outBlack, outWhite)
+}
+
+// CurveLUT returns the lookup table of a curve for values up to max. Each control point maps an input
+// value X to an output value Y, both from 0 to max. The curve is a monotone cubic spline through the
+// points, so it does not overshoot between them, and stays flat before the first point and after the last.
+// The max value must be from 1 to 255.
+func CurveLUT(max int, points []PointF) ([]uint8, error) {
+	return raster.CurveLUT(max, points)
+}
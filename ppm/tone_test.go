@@ -0,0 +1,68 @@
+package Netpbm
+
+import "testing"
+
+// checkPixels compares the only row of a PPM image with the expected pixels.
+func checkPixels(t *testing.T, name string, ppm *PPM, expected ...Pixel) {
+	t.Helper()
+	for x, p := range expected {
+		if ppm.data[0][x] != p {
+			t.Errorf("%s: got %v, expected %v", name, ppm.data[0], expected)
+			return
+		}
+	}
+}
+
+func TestTonePPM(t *testing.T) {
+	ppm := newRowPPM(Pixel{0, 64, 255})
+	ppm.max = 255
+	if err := ppm.Gamma(0.5); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Gamma", ppm, Pixel{0, 16, 255})
+	if _, err := GammaLUT(0, 2); err == nil {
+		t.Error("Expected an error for a max value of 0")
+	}
+	if _, err := GammaLUT(300, 1); err == nil {
+		t.Error("Expected an error for a max value above 255")
+	}
+	if _, err := BrightnessContrastLUT(-3, 0, 0); err == nil {
+		t.Error("Expected an error for a negative max value")
+	}
+
+	if err := ppm.Levels(0, 255, 1, 255, 0); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Levels", ppm, Pixel{255, 239, 0})
+
+	if err := ppm.BrightnessContrast(-0.2, 0); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "BrightnessContrast", ppm, Pixel{204, 188, 0})
+
+	if err := ppm.Curves([]PointF{{X: 0, Y: 255}, {X: 255, Y: 0}}); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "Curves", ppm, Pixel{51, 67, 255})
+}
+
+func TestApplyChannelLUTs(t *testing.T) {
+	ppm := newRowPPM(Pixel{10, 10, 10})
+	ppm.max = 15
+	identity, err := LevelsLUT(15, 0, 15, 1, 0, 15)
+	if err != nil {
+		t.Error(err)
+	}
+	inverted, err := LevelsLUT(15, 0, 15, 1, 15, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if err := ppm.ApplyChannelLUTs(identity, inverted, identity); err != nil {
+		t.Error(err)
+	}
+	checkPixels(t, "ApplyChannelLUTs", ppm, Pixel{10, 5, 10})
+
+	if err := ppm.ApplyChannelLUTs(identity, identity, make([]uint8, 256)); err == nil {
+		t.Error("Expected an error for a lookup table of the wrong size")
+	}
+}
@@ -0,0 +1,71 @@
+package Netpbm
+
+import (
+	"errors"
+
+	"github.com/dolobe/Netpbm/internal/raster"
+)
+
+// PointF represents a point with sub-pixel coordinates.
+type PointF = raster.PointF
+
+// AffineMatrix maps a point (x, y) to (A*x + B*y + C, D*x + E*y + F).
+type AffineMatrix = raster.AffineMatrix
+
+// IdentityMatrix returns the affine matrix that leaves points unchanged.
+func IdentityMatrix() AffineMatrix {
+	return raster.IdentityMatrix()
+}
+
+// TranslationMatrix returns the affine matrix that moves points by (tx, ty).
+func TranslationMatrix(tx, ty float64) AffineMatrix {
+	return raster.TranslationMatrix(tx, ty)
+}
+
+// ScaleMatrix returns the affine matrix that scales points by (sx, sy) around the origin.
+func ScaleMatrix(sx, sy float64) AffineMatrix {
+	return raster.ScaleMatrix(sx, sy)
+}
+
+// RotationMatrix returns the affine matrix that rotates points by angle degrees
+// counterclockwise (as seen on screen, y pointing down) around the origin.
+func RotationMatrix(angle float64) AffineMatrix {
+	return raster.RotationMatrix(angle)
+}
+
+// ShearMatrix returns the affine matrix that shears points by kx horizontally and ky vertically.
+func ShearMatrix(kx, ky float64) AffineMatrix {
+	return raster.ShearMatrix(kx, ky)
+}
+
+// Affine maps the PPM image through m onto a width x height canvas.
+// Pixel centers are at integer coordinates, and destination pixels without a source take the fill color.
+func (ppm *PPM) Affine(m AffineMatrix, width, height int, interp Interpolation, fill Pixel) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid size")
+	}
+	inverse, err := m.Invert()
+	if err != nil {
+		return err
+	}
+	ppm.warp(width, height, func(x, y float64) (float64, float64, bool) {
+		p := inverse.Apply(PointF{X: x, Y: y})
+		return p.X, p.Y, true
+	}, interp, fill)
+	return nil
+}
+
+// Perspective warps the PPM image onto a width x height canvas so that each of the four
+// source corners lands on the matching destination corner.
+// Destination pixels without a source take the fill color.
+func (ppm *PPM) Perspective(src, dst [4]PointF, width, height int, interp Interpolation, fill Pixel) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid size")
+	}
+	inverse, err := raster.Perspective(src, dst)
+	if err != nil {
+		return err
+	}
+	ppm.warp(width, height, inverse, interp, fill)
+	return nil
+}
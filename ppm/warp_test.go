@@ -0,0 +1,72 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMAffine(t *testing.T) {
+	fill := Pixel{R: 9, G: 9, B: 9}
+	ppm := newOrientationPPM()
+	if err := ppm.Affine(TranslationMatrix(1, 0), 3, 2, NearestNeighbor, fill); err != nil {
+		t.Error(err)
+	}
+	expected := [][]Pixel{
+		{fill, {1, 2, 3}, {2, 4, 6}},
+		{fill, {4, 8, 12}, {5, 10, 15}},
+	}
+	for y := range expected {
+		for x := range expected[y] {
+			if ppm.data[y][x] != expected[y][x] {
+				t.Errorf("Pixel at (%d, %d) not translated correctly, got %v", x, y, ppm.data[y][x])
+			}
+		}
+	}
+
+	ppm = newOrientationPPM()
+	if err := ppm.Affine(ScaleMatrix(2, 2), 6, 4, NearestNeighbor, fill); err != nil {
+		t.Error(err)
+	}
+	if ppm.width != 6 || ppm.height != 4 || ppm.data[3][5] != (Pixel{6, 12, 18}) {
+		t.Error("Image not scaled correctly")
+	}
+}
+
+func TestPPMPerspective(t *testing.T) {
+	color := Pixel{R: 200, G: 100, B: 50}
+	ppm := NewPPM(20, 20)
+	ppm.DrawFilledRectangle(Point{0, 0}, 20, 20, color)
+	src := [4]PointF{{X: 0, Y: 0}, {X: 19, Y: 0}, {X: 19, Y: 19}, {X: 0, Y: 19}}
+	dst := [4]PointF{{X: 5, Y: 0}, {X: 14, Y: 0}, {X: 19, Y: 19}, {X: 0, Y: 19}}
+	if err := ppm.Perspective(src, dst, 20, 20, Bilinear, Pixel{}); err != nil {
+		t.Error(err)
+	}
+	if ppm.data[0][0] != (Pixel{}) || ppm.data[0][19] != (Pixel{}) {
+		t.Error("Corners outside the trapezoid not filled")
+	}
+	if ppm.data[0][10] != color || ppm.data[19][0] != color || ppm.data[10][10] != color {
+		t.Error("Inside of the trapezoid not warped correctly")
+	}
+}
+
+func TestPPMPerspectiveConverging(t *testing.T) {
+	// The horizon of this projection lies between the origin and the destination quad.
+	color, fill := Pixel{R: 200, G: 100, B: 50}, Pixel{}
+	src := [4]PointF{{X: 0, Y: 0}, {X: 99, Y: 0}, {X: 99, Y: 99}, {X: 0, Y: 99}}
+	dst := [4]PointF{{X: 145, Y: 100}, {X: 155, Y: 100}, {X: 250, Y: 199}, {X: 50, Y: 199}}
+	ppm := NewPPM(100, 100)
+	ppm.DrawFilledRectangle(Point{0, 0}, 100, 100, color)
+	if err := ppm.Perspective(src, dst, 300, 300, NearestNeighbor, fill); err != nil {
+		t.Error(err)
+	}
+
+	// Rows down the middle of the trapezoid, from its narrow top to its wide bottom.
+	for y := 102; y < 198; y += 8 {
+		half := 5 + (y-100)*95/99 - 1
+		for x := 150 - half; x <= 150+half; x++ {
+			if ppm.data[y][x] != color {
+				t.Errorf("Pixel at (%d, %d) inside the quad not covered", x, y)
+			}
+		}
+	}
+	if ppm.data[50][150] != fill || ppm.data[250][150] != fill || ppm.data[150][10] != fill {
+		t.Error("Pixels outside the quad not filled")
+	}
+}